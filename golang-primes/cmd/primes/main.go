@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pchuck/infinite-series/golang-primes/prime"
+	"github.com/pchuck/infinite-series/golang-primes/prime/output"
+)
+
+var (
+	n         int
+	parallel  bool
+	workers   int
+	segment   int
+	quiet     bool
+	wheel     bool
+	format    string
+	outPath   string
+	gzipOut   bool
+	resume    string
+	ckptEvery int
+)
+
+func init() {
+	flag.IntVar(&n, "n", 0, "Upper bound (exclusive) for prime generation")
+	flag.BoolVar(&parallel, "parallel", false, "Use parallel processing (for large n)")
+	flag.BoolVar(&quiet, "quiet", false, "Only print count (no prime list)")
+	flag.IntVar(&workers, "workers", 0, "Number of worker goroutines (default: NumCPU)")
+	flag.IntVar(&segment, "segment", prime.DefaultSegmentSize, "Segment size for segmented sieve")
+	flag.BoolVar(&wheel, "wheel", false, "Use mod-30 wheel factorization for segmented sieves")
+	flag.StringVar(&format, "format", "text", "Output format: text, u64, or varint")
+	flag.StringVar(&outPath, "output", "", "Write primes to this file instead of stdout")
+	flag.StringVar(&outPath, "o", "", "Shorthand for --output")
+	flag.BoolVar(&gzipOut, "gzip", false, "Gzip-compress the output")
+	flag.StringVar(&resume, "resume", "", "Checkpoint file to resume from and save progress to (streaming only)")
+	flag.IntVar(&ckptEvery, "checkpoint-every", 100, "Segments between checkpoint saves, with --resume")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Prime Number Generator\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags] [n]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s 100                 # Generate primes < 100\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s 100000000 --parallel # Parallel processing\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s 1000000000 --quiet  # Count only, no output\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s 100000000 --wheel    # Mod-30 wheel factorization\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s 10000000000 --format varint --gzip -o primes.bin.gz\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "                         # Compact on-disk table, streamed (no full slice in RAM)\n")
+		fmt.Fprintf(os.Stderr, "  %s 10000000000 --resume primes.ckpt -o primes.bin\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "                         # Resumable: rerun the same command after an interruption\n")
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	if flag.NArg() > 0 && n == 0 {
+		parsed, err := strconv.Atoi(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid number %q: %v\n", flag.Arg(0), err)
+			os.Exit(1)
+		}
+		n = parsed
+	}
+
+	if n <= 0 {
+		fmt.Fprint(os.Stderr, "Enter upper bound (n): ")
+		reader := bufio.NewReader(os.Stdin)
+		input, _ := reader.ReadString('\n')
+		parsed, err := strconv.Atoi(strings.TrimSpace(input))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid number %q: %v\n", strings.TrimSpace(input), err)
+			os.Exit(1)
+		}
+		n = parsed
+	}
+
+	if n <= 2 {
+		fmt.Printf("No primes less than %d\n", n)
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts := []prime.Option{
+		prime.WithContext(ctx),
+		prime.WithParallel(parallel),
+		prime.WithWorkers(workers),
+		prime.WithSegmentSize(segment),
+	}
+	if wheel {
+		opts = append(opts, prime.WithWheel(30))
+	}
+
+	if outPath != "" || format != "text" || gzipOut || resume != "" {
+		runStreaming(n, opts)
+		return
+	}
+
+	computeStart := time.Now()
+	primes := prime.Generate(n, opts...)
+
+	if len(primes) > 0 {
+		if !quiet {
+			fmt.Printf("Primes less than %d: ", n)
+			var sb strings.Builder
+			sb.Grow(len(primes) * 8)
+			for i, p := range primes {
+				if i > 0 {
+					sb.WriteString(", ")
+				}
+				sb.WriteString(strconv.Itoa(p))
+			}
+			fmt.Println(sb.String())
+			fmt.Printf("Total primes: %d\n", len(primes))
+		} else {
+			fmt.Printf("%d\n", len(primes))
+		}
+	} else {
+		fmt.Printf("No primes less than %d\n", n)
+	}
+
+	totalTime := time.Since(computeStart)
+	fmt.Fprintf(os.Stderr, "Done! Generated %d primes in %.3fs.\n", len(primes), totalTime.Seconds())
+}
+
+// newFormatEncoder maps the --format flag to the matching prime/output
+// encoder constructor.
+func newFormatEncoder(f string) (func(w io.Writer) output.Encoder, error) {
+	switch f {
+	case "text":
+		return output.NewText, nil
+	case "u64":
+		return output.NewFixedWidth, nil
+	case "varint":
+		return output.NewVarint, nil
+	default:
+		return nil, fmt.Errorf("unknown --format %q (want text, u64, or varint)", f)
+	}
+}
+
+// runStreaming drives prime.Stream through the requested encoder so a run
+// like `primes 10000000000 --format varint --gzip -o primes.bin.gz` writes a
+// compact on-disk table without ever holding all primes in memory at once.
+func runStreaming(n int, opts []prime.Option) {
+	newEncoder, err := newFormatEncoder(format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if resume != "" {
+		opts = append(opts, prime.WithCheckpoint(resume, ckptEvery))
+	}
+
+	w := io.Writer(os.Stdout)
+	if outPath != "" {
+		f, err := os.Create(outPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	var enc output.Encoder
+	if gzipOut {
+		enc = output.NewGzip(w, newEncoder)
+	} else {
+		enc = newEncoder(w)
+	}
+
+	computeStart := time.Now()
+	var count int64
+	for p := range prime.Stream(n, opts...) {
+		if err := enc.WriteUint64(uint64(p)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing prime %d: %v\n", p, err)
+			os.Exit(1)
+		}
+		count++
+	}
+	if err := enc.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error closing encoder: %v\n", err)
+		os.Exit(1)
+	}
+
+	totalTime := time.Since(computeStart)
+	fmt.Fprintf(os.Stderr, "Done! Generated %d primes in %.3fs.\n", count, totalTime.Seconds())
+}
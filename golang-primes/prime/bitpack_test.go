@@ -0,0 +1,53 @@
+package prime
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBitPackedSegmentedSieveMatchesByteBuffer(t *testing.T) {
+	tests := []int{10, 100, 1000, 100000}
+	for _, n := range tests {
+		want := SegmentedSieve(n, 10, nil)
+		got := bitPackedSegmentedSieveCtx(context.Background(), n, 10, nil)
+
+		if len(got) != len(want) {
+			t.Fatalf("bitPackedSegmentedSieveCtx(%d) produced %d primes, want %d", n, len(got), len(want))
+		}
+		for i, v := range want {
+			if got[i] != v {
+				t.Errorf("bitPackedSegmentedSieveCtx(%d)[%d] = %d, want %d", n, i, got[i], v)
+			}
+		}
+	}
+}
+
+func TestParallelBitPackedSegmentedSieveMatchesSequential(t *testing.T) {
+	n := 200000
+	seq := bitPackedSegmentedSieveCtx(context.Background(), n, 100, nil)
+	par := parallelBitPackedSegmentedSieveCtx(context.Background(), n, 2, 100, nil)
+
+	if len(seq) != len(par) {
+		t.Fatalf("parallelBitPackedSegmentedSieveCtx(%d) length = %d, want %d", n, len(par), len(seq))
+	}
+	for i, v := range seq {
+		if par[i] != v {
+			t.Errorf("parallelBitPackedSegmentedSieveCtx(%d)[%d] = %d, want %d", n, i, par[i], v)
+		}
+	}
+}
+
+func TestGenerateWithByteBufferOption(t *testing.T) {
+	n := DefaultSegmentSize
+	want := Generate(n)
+	got := Generate(n, WithByteBuffer(true))
+
+	if len(got) != len(want) {
+		t.Fatalf("Generate(%d, WithByteBuffer(true)) length = %d, want %d", n, len(got), len(want))
+	}
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("Generate(%d, WithByteBuffer(true))[%d] = %d, want %d", n, i, got[i], v)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package prime
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Checkpoint records enough state for a Stream run over very large n to be
+// interrupted and resumed: which segments are already done, how many primes
+// they produced, and a sha256 over the primes emitted since the run started
+// (meant as an integrity check against the append-only output file a caller
+// is expected to be writing via package output -- not a cumulative hash
+// across every resume, since recomputing that would require re-reading the
+// whole output file, which Checkpoint deliberately never opens).
+type Checkpoint struct {
+	N                   int    `json:"n"`
+	SegmentSize         int    `json:"segment_size"`
+	Wheel               int    `json:"wheel"`
+	NextSegmentIdx      int    `json:"next_segment_idx"`
+	PrimeCountSoFar     int64  `json:"prime_count_so_far"`
+	EmittedPrimesSHA256 string `json:"emitted_primes_sha256"`
+}
+
+// SaveCheckpoint writes cp to path via a temp file plus rename, so a crash
+// mid-write never leaves a half-written checkpoint for LoadCheckpoint to
+// trip over.
+func SaveCheckpoint(path string, cp Checkpoint) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadCheckpoint reads a checkpoint written by SaveCheckpoint.
+func LoadCheckpoint(path string) (Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	var cp Checkpoint
+	err = json.Unmarshal(data, &cp)
+	return cp, err
+}
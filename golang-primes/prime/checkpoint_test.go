@@ -0,0 +1,97 @@
+package prime
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoadCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	want := Checkpoint{
+		N:                   1000000,
+		SegmentSize:         1000,
+		NextSegmentIdx:      42,
+		PrimeCountSoFar:     1229,
+		EmittedPrimesSHA256: "deadbeef",
+	}
+
+	if err := SaveCheckpoint(path, want); err != nil {
+		t.Fatalf("SaveCheckpoint: %v", err)
+	}
+
+	got, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint: %v", err)
+	}
+	if got != want {
+		t.Errorf("LoadCheckpoint = %+v, want %+v", got, want)
+	}
+}
+
+func TestStreamResumesFromCheckpoint(t *testing.T) {
+	n := 50000
+	segmentSize := 1000
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	want := Generate(n)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var firstRun []int
+	for p := range Stream(n, WithContext(ctx), WithSegmentSize(segmentSize), WithCheckpoint(path, 1)) {
+		firstRun = append(firstRun, p)
+		if len(firstRun) == len(want)/2 {
+			cancel()
+		}
+	}
+
+	cp, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint after partial run: %v", err)
+	}
+	if cp.NextSegmentIdx == 0 {
+		t.Fatalf("checkpoint was never advanced past segment 0")
+	}
+
+	var secondRun []int
+	for p := range Stream(n, WithSegmentSize(segmentSize), WithCheckpoint(path, 1)) {
+		secondRun = append(secondRun, p)
+	}
+
+	cutoff := cp.NextSegmentIdx * segmentSize
+	var wantTail []int
+	for _, p := range want {
+		if p >= cutoff {
+			wantTail = append(wantTail, p)
+		}
+	}
+
+	if len(secondRun) != len(wantTail) {
+		t.Fatalf("resumed Stream produced %d primes, want %d", len(secondRun), len(wantTail))
+	}
+	for i, v := range wantTail {
+		if secondRun[i] != v {
+			t.Errorf("resumed Stream[%d] = %d, want %d", i, secondRun[i], v)
+		}
+	}
+}
+
+func TestStreamCheckpointIgnoredOnMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	if err := SaveCheckpoint(path, Checkpoint{N: 999, SegmentSize: 500, NextSegmentIdx: 10}); err != nil {
+		t.Fatalf("SaveCheckpoint: %v", err)
+	}
+
+	n := 10000
+	want := Generate(n)
+
+	var got []int
+	for p := range Stream(n, WithContext(context.Background()), WithSegmentSize(1000), WithCheckpoint(path, 1)) {
+		got = append(got, p)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Stream with mismatched checkpoint produced %d primes, want %d (full run)", len(got), len(want))
+	}
+}
@@ -0,0 +1,138 @@
+package prime
+
+import "context"
+
+// genConfig holds the resolved settings for Generate.
+type genConfig struct {
+	ctx             context.Context
+	parallel        bool
+	workers         int
+	segmentSize     int
+	progress        func(int)
+	wheel           int
+	byteBuffer      bool
+	checkpointPath  string
+	checkpointEvery int
+}
+
+// Option configures a Generate call.
+type Option func(*genConfig)
+
+// WithContext makes Generate honor cancellation: workers stop picking up
+// queued segments and the producer stops enqueueing new ones as soon as
+// ctx is done, so a sieve over billions can be aborted cleanly instead of
+// running to completion.
+func WithContext(ctx context.Context) Option {
+	return func(c *genConfig) { c.ctx = ctx }
+}
+
+// WithParallel selects Generate's parallel sieve over its sequential one once
+// n reaches ParallelThreshold.
+func WithParallel(parallel bool) Option {
+	return func(c *genConfig) { c.parallel = parallel }
+}
+
+// WithWorkers sets the worker pool size for the parallel path; <= 0 means
+// runtime.NumCPU(), matching ParallelSegmentedSieve's own default.
+func WithWorkers(workers int) Option {
+	return func(c *genConfig) { c.workers = workers }
+}
+
+// WithSegmentSize overrides DefaultSegmentSize.
+func WithSegmentSize(segmentSize int) Option {
+	return func(c *genConfig) { c.segmentSize = segmentSize }
+}
+
+// WithProgress registers a callback invoked with the number of segments
+// completed since the last call.
+func WithProgress(progress func(int)) Option {
+	return func(c *genConfig) { c.progress = progress }
+}
+
+// WithWheel selects wheel factorization over the default odd-only (mod-2)
+// sieve. Only modulus 30 is currently supported (WheelSegmentedSieve); any
+// other value leaves Generate on its odd-only path.
+func WithWheel(modulus int) Option {
+	return func(c *genConfig) { c.wheel = modulus }
+}
+
+// WithByteBuffer opts back into the []byte-per-candidate segment buffer
+// (sieveSegmentOddOnly) instead of Generate's default bit-packed []uint64
+// buffer (sieveSegmentBitPacked), for comparing the two in benchmarks. Has
+// no effect together with WithWheel, which always uses its own byte buffer.
+func WithByteBuffer(byteBuffer bool) Option {
+	return func(c *genConfig) { c.byteBuffer = byteBuffer }
+}
+
+// WithCheckpoint makes Stream resumable: after every everyNSegments
+// completed segments it atomically saves a Checkpoint to path, and a later
+// Stream call with the same path, n, and segment size skips segments
+// already recorded as done rather than re-sieving them. everyNSegments <= 0
+// disables the periodic saves; a final checkpoint is still written when the
+// stream runs to completion, and an existing checkpoint at path is still
+// honored for skipping.
+func WithCheckpoint(path string, everyNSegments int) Option {
+	return func(c *genConfig) {
+		c.checkpointPath = path
+		c.checkpointEvery = everyNSegments
+	}
+}
+
+// genConfigFromOptions resolves opts against the same defaults Generate
+// uses, for entry points like Stream and Count that need a genConfig but
+// don't sieve via Generate's own dispatch logic.
+func genConfigFromOptions(opts []Option) genConfig {
+	cfg := genConfig{
+		ctx:         context.Background(),
+		segmentSize: DefaultSegmentSize,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.segmentSize <= 0 {
+		cfg.segmentSize = DefaultSegmentSize
+	}
+	return cfg
+}
+
+// Generate is the functional-options entry point for sieving primes below
+// n, replacing the positional-bool/separate-function mix of GeneratePrimes,
+// SegmentedSieve, and ParallelSegmentedSieve with a single call site that
+// also supports cancellation via WithContext.
+func Generate(n int, opts ...Option) []int {
+	cfg := genConfigFromOptions(opts)
+
+	if n <= 2 {
+		return nil
+	}
+
+	if cfg.wheel == 30 {
+		if cfg.parallel && n >= ParallelThreshold {
+			return ParallelWheelSegmentedSieve(cfg.ctx, n, cfg.workers, cfg.segmentSize, cfg.progress)
+		}
+		if n >= DefaultSegmentSize {
+			return WheelSegmentedSieve(cfg.ctx, n, cfg.segmentSize, cfg.progress)
+		}
+		return SieveOfEratosthenes(n)
+	}
+
+	if cfg.byteBuffer {
+		if cfg.parallel && n >= ParallelThreshold {
+			return parallelSegmentedSieveCtx(cfg.ctx, n, cfg.workers, cfg.segmentSize, cfg.progress)
+		}
+		if n >= DefaultSegmentSize {
+			return segmentedSieveCtx(cfg.ctx, n, cfg.segmentSize, cfg.progress)
+		}
+		return SieveOfEratosthenes(n)
+	}
+
+	if cfg.parallel && n >= ParallelThreshold {
+		return parallelBitPackedSegmentedSieveCtx(cfg.ctx, n, cfg.workers, cfg.segmentSize, cfg.progress)
+	}
+
+	if n >= DefaultSegmentSize {
+		return bitPackedSegmentedSieveCtx(cfg.ctx, n, cfg.segmentSize, cfg.progress)
+	}
+
+	return SieveOfEratosthenes(n)
+}
@@ -0,0 +1,62 @@
+package prime
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenerateMatchesGeneratePrimes(t *testing.T) {
+	n := 100000
+	legacy := GeneratePrimes(n, false, nil)
+	opts := Generate(n)
+
+	if len(legacy) != len(opts) {
+		t.Fatalf("Generate(%d) length = %d, want %d", n, len(opts), len(legacy))
+	}
+	for i, v := range legacy {
+		if v != opts[i] {
+			t.Errorf("Generate(%d)[%d] = %d, want %d", n, i, opts[i], v)
+		}
+	}
+}
+
+func TestGenerateWithParallelOption(t *testing.T) {
+	// n must reach ParallelThreshold, or Generate's dispatch never takes the
+	// parallel branch and this test would pass regardless of whether the
+	// parallel wiring is correct.
+	n := ParallelThreshold
+	seq := Generate(n, WithParallel(false))
+	par := Generate(n, WithParallel(true), WithWorkers(2))
+
+	if len(seq) != len(par) {
+		t.Fatalf("Generate with parallel option length = %d, want %d", len(par), len(seq))
+	}
+	for i, v := range seq {
+		if v != par[i] {
+			t.Errorf("Generate with parallel option [%d] = %d, want %d", i, par[i], v)
+		}
+	}
+}
+
+func TestGenerateWithContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := Generate(10_000_000, WithContext(ctx), WithSegmentSize(1000))
+
+	if len(result) > 1000 {
+		t.Errorf("Generate with a pre-cancelled context produced %d primes, want it to stop almost immediately", len(result))
+	}
+}
+
+func TestGenerateWithProgressOption(t *testing.T) {
+	n := DefaultSegmentSize
+	completed := 0
+	Generate(n, WithSegmentSize(1000), WithProgress(func(delta int) {
+		completed += delta
+	}))
+
+	if completed == 0 {
+		t.Error("WithProgress callback was not invoked")
+	}
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+)
+
+// fixedWidthEncoder writes each prime as an 8-byte little-endian uint64 --
+// simple, seekable-by-index, but the least compact of these formats.
+type fixedWidthEncoder struct {
+	w   *bufio.Writer
+	buf [8]byte
+}
+
+// NewFixedWidth returns an Encoder that writes each prime as a fixed
+// 8-byte little-endian uint64 to w.
+func NewFixedWidth(w io.Writer) Encoder {
+	return &fixedWidthEncoder{w: bufio.NewWriter(w)}
+}
+
+func (e *fixedWidthEncoder) WriteUint64(p uint64) error {
+	binary.LittleEndian.PutUint64(e.buf[:], p)
+	_, err := e.w.Write(e.buf[:])
+	return err
+}
+
+func (e *fixedWidthEncoder) Close() error {
+	return e.w.Flush()
+}
@@ -0,0 +1,31 @@
+package output
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// gzipEncoder wraps another Encoder's output in gzip compression.
+type gzipEncoder struct {
+	gz    *gzip.Writer
+	inner Encoder
+}
+
+// NewGzip wraps w in gzip compression and builds the encoder newEncoder
+// would build over a plain writer, e.g. NewGzip(f, NewVarint) for a
+// gzipped delta-varint table.
+func NewGzip(w io.Writer, newEncoder func(io.Writer) Encoder) Encoder {
+	gz := gzip.NewWriter(w)
+	return &gzipEncoder{gz: gz, inner: newEncoder(gz)}
+}
+
+func (e *gzipEncoder) WriteUint64(p uint64) error {
+	return e.inner.WriteUint64(p)
+}
+
+func (e *gzipEncoder) Close() error {
+	if err := e.inner.Close(); err != nil {
+		return err
+	}
+	return e.gz.Close()
+}
@@ -0,0 +1,12 @@
+// Package output provides pluggable ways to write a monotonic stream of
+// primes (as produced by prime.Stream) to an io.Writer, without requiring
+// the full list in memory first.
+package output
+
+// Encoder writes a stream of primes, in ascending order, to some
+// destination. Callers must call Close once they're done writing so
+// implementations that buffer or wrap another writer (e.g. gzip) can flush.
+type Encoder interface {
+	WriteUint64(p uint64) error
+	Close() error
+}
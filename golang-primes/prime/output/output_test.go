@@ -0,0 +1,129 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"strconv"
+	"testing"
+)
+
+var testPrimes = []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}
+
+func TestTextRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewText(&buf)
+	for _, p := range testPrimes {
+		if err := enc.WriteUint64(p); err != nil {
+			t.Fatalf("WriteUint64(%d): %v", p, err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	var got []uint64
+	for scanner.Scan() {
+		v, err := strconv.ParseUint(scanner.Text(), 10, 64)
+		if err != nil {
+			t.Fatalf("parse %q: %v", scanner.Text(), err)
+		}
+		got = append(got, v)
+	}
+	assertEqual(t, got, testPrimes)
+}
+
+func TestFixedWidthRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewFixedWidth(&buf)
+	for _, p := range testPrimes {
+		if err := enc.WriteUint64(p); err != nil {
+			t.Fatalf("WriteUint64(%d): %v", p, err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data := buf.Bytes()
+	if len(data) != len(testPrimes)*8 {
+		t.Fatalf("encoded length = %d, want %d", len(data), len(testPrimes)*8)
+	}
+	var got []uint64
+	for i := 0; i < len(data); i += 8 {
+		got = append(got, binary.LittleEndian.Uint64(data[i:i+8]))
+	}
+	assertEqual(t, got, testPrimes)
+}
+
+func TestVarintRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewVarint(&buf)
+	for _, p := range testPrimes {
+		if err := enc.WriteUint64(p); err != nil {
+			t.Fatalf("WriteUint64(%d): %v", p, err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	br := bytes.NewReader(buf.Bytes())
+	var got []uint64
+	var prev uint64
+	for br.Len() > 0 {
+		gap, err := binary.ReadUvarint(br)
+		if err != nil {
+			t.Fatalf("ReadUvarint: %v", err)
+		}
+		prev += gap
+		got = append(got, prev)
+	}
+	assertEqual(t, got, testPrimes)
+}
+
+func TestGzipRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewGzip(&buf, NewVarint)
+	for _, p := range testPrimes {
+		if err := enc.WriteUint64(p); err != nil {
+			t.Fatalf("WriteUint64(%d): %v", p, err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	gr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	defer gr.Close()
+
+	br := bufio.NewReader(gr)
+	var got []uint64
+	var prev uint64
+	for {
+		gap, err := binary.ReadUvarint(br)
+		if err != nil {
+			break
+		}
+		prev += gap
+		got = append(got, prev)
+	}
+	assertEqual(t, got, testPrimes)
+}
+
+func assertEqual(t *testing.T, got, want []uint64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("[%d] = %d, want %d", i, got[i], v)
+		}
+	}
+}
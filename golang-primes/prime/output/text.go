@@ -0,0 +1,27 @@
+package output
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+)
+
+// textEncoder writes one decimal prime per line, the simplest Encoder
+// implementation and the baseline the other formats are compared against.
+type textEncoder struct {
+	w *bufio.Writer
+}
+
+// NewText returns an Encoder that writes decimal primes to w, one per line.
+func NewText(w io.Writer) Encoder {
+	return &textEncoder{w: bufio.NewWriter(w)}
+}
+
+func (e *textEncoder) WriteUint64(p uint64) error {
+	_, err := e.w.WriteString(strconv.FormatUint(p, 10) + "\n")
+	return err
+}
+
+func (e *textEncoder) Close() error {
+	return e.w.Flush()
+}
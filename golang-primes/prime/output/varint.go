@@ -0,0 +1,35 @@
+package output
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+)
+
+// varintEncoder writes each prime as the varint-encoded gap from the
+// previous one (the first prime is written as itself). Gaps between primes
+// stay small even at large n, so this compresses roughly 8x versus
+// NewFixedWidth for the same sequence.
+type varintEncoder struct {
+	w    *bufio.Writer
+	buf  [binary.MaxVarintLen64]byte
+	prev uint64
+}
+
+// NewVarint returns an Encoder that writes delta-encoded varints to w.
+// Primes must be written in ascending order, same as every other Encoder
+// here.
+func NewVarint(w io.Writer) Encoder {
+	return &varintEncoder{w: bufio.NewWriter(w)}
+}
+
+func (e *varintEncoder) WriteUint64(p uint64) error {
+	n := binary.PutUvarint(e.buf[:], p-e.prev)
+	e.prev = p
+	_, err := e.w.Write(e.buf[:n])
+	return err
+}
+
+func (e *varintEncoder) Close() error {
+	return e.w.Flush()
+}
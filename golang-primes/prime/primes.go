@@ -2,7 +2,9 @@ package prime
 
 import (
 	"bytes"
+	"context"
 	"math"
+	"math/bits"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -28,8 +30,38 @@ func sieveSegmentOddOnly(low, high int, basePrimes []int, isPrime []byte) []int
 		primes = append(primes, 2)
 	}
 
+	oddLow, segLen := markSegmentOddOnly(low, high, basePrimes, isPrime)
+	if segLen <= 0 {
+		return primes
+	}
+
+	// Extract primes using bytes.IndexByte for SIMD-optimized search
+	data := isPrime[:segLen]
+	idx := 0
+	for {
+		pos := bytes.IndexByte(data[idx:], 1)
+		if pos == -1 {
+			break
+		}
+		idx += pos
+		primes = append(primes, oddLow+2*idx)
+		idx++
+		if idx >= segLen {
+			break
+		}
+	}
+
+	return primes
+}
+
+// markSegmentOddOnly resets isPrime and crosses off composites for the
+// odd-only sieve of [low, high), the shared first half of sieveSegmentOddOnly
+// and countSegmentOddOnly. It returns oddLow (the number index 0 of isPrime
+// represents) and segLen (the number of valid entries in isPrime); segLen <= 0
+// means the segment holds no odd candidates and isPrime was left untouched.
+func markSegmentOddOnly(low, high int, basePrimes []int, isPrime []byte) (oddLow, segLen int) {
 	// Odd-only sieve: index i represents number oddLow + 2*i
-	oddLow := low
+	oddLow = low
 	if oddLow < 3 {
 		oddLow = 3
 	}
@@ -37,12 +69,12 @@ func sieveSegmentOddOnly(low, high int, basePrimes []int, isPrime []byte) []int
 		oddLow++
 	}
 	if oddLow >= high {
-		return primes
+		return oddLow, 0
 	}
 
-	segLen := (high - oddLow + 1) / 2 // count of odd numbers in [oddLow, high)
+	segLen = (high - oddLow + 1) / 2 // count of odd numbers in [oddLow, high)
 	if segLen <= 0 {
-		return primes
+		return oddLow, segLen
 	}
 
 	// Reset buffer (no allocation -- just memset the portion we need)
@@ -72,23 +104,27 @@ func sieveSegmentOddOnly(low, high int, basePrimes []int, isPrime []byte) []int
 		}
 	}
 
-	// Extract primes using bytes.IndexByte for SIMD-optimized search
-	data := isPrime[:segLen]
-	idx := 0
-	for {
-		pos := bytes.IndexByte(data[idx:], 1)
-		if pos == -1 {
-			break
-		}
-		idx += pos
-		primes = append(primes, oddLow+2*idx)
-		idx++
-		if idx >= segLen {
-			break
-		}
+	return oddLow, segLen
+}
+
+// countSegmentOddOnly is sieveSegmentOddOnly without the extraction loop: it
+// marks composites exactly as sieveSegmentOddOnly does, then tallies
+// survivors with bits.OnesCount8 instead of appending each one to a []int.
+// Since isPrime holds a 0/1 value per byte, OnesCount8 of a single byte is
+// just that byte's value, but summing it this way skips the branch-per-byte
+// bytes.IndexByte scan does and lets the compiler use a single wide reduction.
+func countSegmentOddOnly(low, high int, basePrimes []int, isPrime []byte) int64 {
+	var count int64
+	if low <= 2 && high > 2 {
+		count++
 	}
 
-	return primes
+	_, segLen := markSegmentOddOnly(low, high, basePrimes, isPrime)
+	for _, b := range isPrime[:segLen] {
+		count += int64(bits.OnesCount8(b))
+	}
+
+	return count
 }
 
 func SieveOfEratosthenes(n int) []int {
@@ -144,6 +180,13 @@ func SieveOfEratosthenes(n int) []int {
 }
 
 func SegmentedSieve(n int, segmentSize int, progress func(int)) []int {
+	return segmentedSieveCtx(context.Background(), n, segmentSize, progress)
+}
+
+// segmentedSieveCtx is SegmentedSieve with cancellation: ctx is checked
+// between segments, returning whatever primes were found so far once it's
+// done.
+func segmentedSieveCtx(ctx context.Context, n int, segmentSize int, progress func(int)) []int {
 	if n <= 2 {
 		return nil
 	}
@@ -169,6 +212,12 @@ func SegmentedSieve(n int, segmentSize int, progress func(int)) []int {
 	isPrime := make([]byte, segmentSize)
 
 	for segIdx := 0; segIdx < segments; segIdx++ {
+		select {
+		case <-ctx.Done():
+			return primes
+		default:
+		}
+
 		low := segIdx * segmentSize
 		high := low + segmentSize
 		if high > n {
@@ -205,6 +254,7 @@ type segmentResult struct {
 }
 
 func workerProcessSegment(
+	ctx context.Context,
 	workChan <-chan segmentWork,
 	resultsChan chan<- segmentResult,
 	basePrimes []int,
@@ -216,7 +266,18 @@ func workerProcessSegment(
 	// Each worker gets its own reusable buffer
 	isPrime := make([]byte, segmentSize)
 
-	for work := range workChan {
+	for {
+		var work segmentWork
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return
+		case work, ok = <-workChan:
+			if !ok {
+				return
+			}
+		}
+
 		if work.high <= 2 {
 			atomic.AddInt64(completedSegments, 1)
 			resultsChan <- segmentResult{segIdx: work.segIdx, primes: nil}
@@ -234,6 +295,13 @@ func workerProcessSegment(
 }
 
 func ParallelSegmentedSieve(n int, workers, segmentSize int, progress func(int)) []int {
+	return parallelSegmentedSieveCtx(context.Background(), n, workers, segmentSize, progress)
+}
+
+// parallelSegmentedSieveCtx is ParallelSegmentedSieve with cancellation: ctx
+// is honored both by the producer (stops enqueuing segments) and by each
+// worker (stops picking up queued segments).
+func parallelSegmentedSieveCtx(ctx context.Context, n int, workers, segmentSize int, progress func(int)) []int {
 	if n <= 2 {
 		return nil
 	}
@@ -269,11 +337,12 @@ func ParallelSegmentedSieve(n int, workers, segmentSize int, progress func(int))
 	// Start workers
 	for i := 0; i < numWorkers; i++ {
 		wg.Add(1)
-		go workerProcessSegment(workChan, resultsChan, basePrimesOdd, segmentSize, &wg, &completedSegments)
+		go workerProcessSegment(ctx, workChan, resultsChan, basePrimesOdd, segmentSize, &wg, &completedSegments)
 	}
 
 	// Producer: enqueue all segments
 	go func() {
+		defer close(workChan)
 		for segIdx := 0; segIdx < segments; segIdx++ {
 			low := segIdx * segmentSize
 			high := low + segmentSize
@@ -281,13 +350,16 @@ func ParallelSegmentedSieve(n int, workers, segmentSize int, progress func(int))
 				high = n
 			}
 
-			workChan <- segmentWork{
+			select {
+			case <-ctx.Done():
+				return
+			case workChan <- segmentWork{
 				segIdx: segIdx,
 				low:    low,
 				high:   high,
+			}:
 			}
 		}
-		close(workChan)
 	}()
 
 	// Closer: wait for all workers then close results
@@ -340,20 +412,10 @@ func ParallelSegmentedSieve(n int, workers, segmentSize int, progress func(int))
 	return allPrimes
 }
 
+// GeneratePrimes is kept for backward compatibility; new callers should
+// prefer Generate's functional options, e.g. for cancellation via WithContext.
 func GeneratePrimes(n int, parallel bool, progress func(int)) []int {
-	if n <= 2 {
-		return nil
-	}
-
-	if parallel && n >= ParallelThreshold {
-		return ParallelSegmentedSieve(n, 0, DefaultSegmentSize, progress)
-	}
-
-	if n >= DefaultSegmentSize {
-		return SegmentedSieve(n, DefaultSegmentSize, progress)
-	}
-
-	return SieveOfEratosthenes(n)
+	return Generate(n, WithParallel(parallel), WithProgress(progress))
 }
 
 // ProgressTracker provides thread-safe progress tracking using atomics.
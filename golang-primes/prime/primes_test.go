@@ -327,19 +327,8 @@ func TestGeneratePrimesProgressParameter(t *testing.T) {
 
 func TestNoComposites(t *testing.T) {
 	primes := GeneratePrimes(50, false, nil)
-	for _, p := range primes {
-		if p <= 1 {
-			t.Errorf("Found non-prime: %d", p)
-		}
-		// Actually verify primality
-		if p > 2 && p%2 == 0 {
-			t.Errorf("Found even composite: %d", p)
-		}
-		for d := 3; d*d <= p; d += 2 {
-			if p%d == 0 {
-				t.Errorf("Found composite: %d (divisible by %d)", p, d)
-			}
-		}
+	if err := VerifyPrimes(primes, false); err != nil {
+		t.Error(err)
 	}
 }
 
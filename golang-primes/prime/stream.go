@@ -0,0 +1,176 @@
+package prime
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"math"
+)
+
+// Stream sieves primes below n segment-by-segment and emits them on the
+// returned channel in ascending order, without ever materializing the full
+// []int that Generate/GeneratePrimes would. This is the natural fit for
+// callers that only fold over primes (sum, write to disk, hash) or that want
+// to stop early via ctx/WithContext.
+//
+// The channel is closed once sieving finishes or opts's context (via
+// WithContext) is done. Stream itself ignores WithParallel: segments are
+// produced by a single goroutine so results stay strictly ordered, but the
+// crossing-off work per segment is identical to the parallel path.
+//
+// With WithCheckpoint, Stream is resumable: segments already recorded as
+// done in the checkpoint are skipped entirely (not re-sieved, not
+// re-emitted) rather than counted against n again.
+func Stream(n int, opts ...Option) <-chan int {
+	cfg := genConfigFromOptions(opts)
+	out := make(chan int)
+
+	go func() {
+		defer close(out)
+
+		if n <= 2 {
+			return
+		}
+
+		baseLimit := int(math.Sqrt(float64(n)))
+		allBasePrimes := SieveOfEratosthenes(baseLimit + 1)
+		basePrimesOdd := make([]int, 0, len(allBasePrimes))
+		for _, p := range allBasePrimes {
+			if p > 2 {
+				basePrimesOdd = append(basePrimesOdd, p)
+			}
+		}
+
+		segments := (n + cfg.segmentSize - 1) / cfg.segmentSize
+		isPrime := make([]byte, cfg.segmentSize)
+
+		startIdx := 0
+		var primeCount int64
+		var hasher hash.Hash
+		if cfg.checkpointPath != "" {
+			hasher = sha256.New()
+			if cp, err := LoadCheckpoint(cfg.checkpointPath); err == nil &&
+				cp.N == n && cp.SegmentSize == cfg.segmentSize && cp.Wheel == cfg.wheel {
+				startIdx = cp.NextSegmentIdx
+				primeCount = cp.PrimeCountSoFar
+			}
+		}
+
+		var numBuf [8]byte
+		segsSinceCheckpoint := 0
+		for segIdx := startIdx; segIdx < segments; segIdx++ {
+			select {
+			case <-cfg.ctx.Done():
+				return
+			default:
+			}
+
+			low := segIdx * cfg.segmentSize
+			high := low + cfg.segmentSize
+			if high > n {
+				high = n
+			}
+
+			if high > 2 {
+				for _, p := range sieveSegmentOddOnly(low, high, basePrimesOdd, isPrime) {
+					select {
+					case <-cfg.ctx.Done():
+						return
+					case out <- p:
+					}
+					primeCount++
+					if hasher != nil {
+						binary.LittleEndian.PutUint64(numBuf[:], uint64(p))
+						hasher.Write(numBuf[:])
+					}
+				}
+			}
+
+			if cfg.progress != nil {
+				cfg.progress(1)
+			}
+
+			if hasher != nil {
+				segsSinceCheckpoint++
+				if cfg.checkpointEvery > 0 && segsSinceCheckpoint >= cfg.checkpointEvery {
+					segsSinceCheckpoint = 0
+					saveStreamCheckpoint(cfg, n, segIdx+1, primeCount, hasher)
+				}
+			}
+		}
+
+		if hasher != nil {
+			saveStreamCheckpoint(cfg, n, segments, primeCount, hasher)
+		}
+	}()
+
+	return out
+}
+
+// saveStreamCheckpoint snapshots hasher's digest-so-far without disturbing
+// it (Hash.Sum doesn't reset the running hash) and persists a Checkpoint
+// recording nextSegmentIdx as the first not-yet-completed segment. Stream
+// has no error channel to report a failed save on, so this is best-effort:
+// a write failure just means the next checkpoint (or a from-scratch rerun)
+// is the fallback.
+func saveStreamCheckpoint(cfg genConfig, n, nextSegmentIdx int, primeCount int64, hasher hash.Hash) {
+	sum := hasher.Sum(nil)
+	_ = SaveCheckpoint(cfg.checkpointPath, Checkpoint{
+		N:                   n,
+		SegmentSize:         cfg.segmentSize,
+		Wheel:               cfg.wheel,
+		NextSegmentIdx:      nextSegmentIdx,
+		PrimeCountSoFar:     primeCount,
+		EmittedPrimesSHA256: hex.EncodeToString(sum),
+	})
+}
+
+// Count returns the number of primes below n without extracting or emitting
+// any of them, by tallying set bits in each segment's sieve buffer directly
+// (see countSegmentOddOnly). This is the fast path for callers like a
+// --quiet CLI flag that only want π(n).
+func Count(n int, opts ...Option) int64 {
+	cfg := genConfigFromOptions(opts)
+
+	if n <= 2 {
+		return 0
+	}
+
+	baseLimit := int(math.Sqrt(float64(n)))
+	allBasePrimes := SieveOfEratosthenes(baseLimit + 1)
+	basePrimesOdd := make([]int, 0, len(allBasePrimes))
+	for _, p := range allBasePrimes {
+		if p > 2 {
+			basePrimesOdd = append(basePrimesOdd, p)
+		}
+	}
+
+	segments := (n + cfg.segmentSize - 1) / cfg.segmentSize
+	isPrime := make([]byte, cfg.segmentSize)
+
+	var count int64
+	for segIdx := 0; segIdx < segments; segIdx++ {
+		select {
+		case <-cfg.ctx.Done():
+			return count
+		default:
+		}
+
+		low := segIdx * cfg.segmentSize
+		high := low + cfg.segmentSize
+		if high > n {
+			high = n
+		}
+
+		if high > 2 {
+			count += countSegmentOddOnly(low, high, basePrimesOdd, isPrime)
+		}
+
+		if cfg.progress != nil {
+			cfg.progress(1)
+		}
+	}
+
+	return count
+}
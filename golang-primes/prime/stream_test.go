@@ -0,0 +1,59 @@
+package prime
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStreamMatchesGenerate(t *testing.T) {
+	n := 100000
+	want := Generate(n)
+
+	var got []int
+	for p := range Stream(n) {
+		got = append(got, p)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Stream(%d) produced %d primes, want %d", n, len(got), len(want))
+	}
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("Stream(%d)[%d] = %d, want %d", n, i, got[i], v)
+		}
+	}
+}
+
+func TestStreamWithContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var got []int
+	for p := range Stream(10_000_000, WithContext(ctx), WithSegmentSize(1000)) {
+		got = append(got, p)
+	}
+
+	if len(got) > 1000 {
+		t.Errorf("Stream with a pre-cancelled context produced %d primes, want it to stop almost immediately", len(got))
+	}
+}
+
+func TestCountMatchesGenerateLength(t *testing.T) {
+	n := 100000
+	want := len(Generate(n))
+
+	got := Count(n)
+	if got != int64(want) {
+		t.Errorf("Count(%d) = %d, want %d", n, got, want)
+	}
+}
+
+func TestCountWithSegmentSize(t *testing.T) {
+	n := 100000
+	want := Count(n)
+
+	got := Count(n, WithSegmentSize(1000))
+	if got != want {
+		t.Errorf("Count(%d) with WithSegmentSize(1000) = %d, want %d", n, got, want)
+	}
+}
@@ -0,0 +1,335 @@
+package prime
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// wheelResidues30 lists the 8 residues mod 30 that are coprime to 2*3*5, in
+// ascending order. Every prime above 5 is 30*block + one of these residues,
+// so a mod-30 wheel sieve only has to store/test 8 candidates per 30
+// integers instead of 15 (odd-only) or 30 (naive).
+var wheelResidues30 = [8]int{1, 7, 11, 13, 17, 19, 23, 29}
+
+// wheelIndex30 maps a residue mod 30 to its index in wheelResidues30, or -1
+// if 2, 3, or 5 divides it.
+var wheelIndex30 [30]int
+
+// wheelGaps30[i] is the distance from wheelResidues30[i] to the next wheel
+// residue, wrapping past 30 after the last one (e.g. wheelGaps30[7] = 31-29
+// = 2, landing on the next block's residue 1).
+var wheelGaps30 [8]int
+
+func init() {
+	for i := range wheelIndex30 {
+		wheelIndex30[i] = -1
+	}
+	for i, r := range wheelResidues30 {
+		wheelIndex30[r] = i
+		next := wheelResidues30[(i+1)%8]
+		if next <= r {
+			next += 30
+		}
+		wheelGaps30[i] = next - r
+	}
+}
+
+// wheelBasePrimes returns the primes above 5 up to sqrt(n), i.e. the base
+// primes needed to cross off composites in a mod-30 wheel sieve (2, 3, and 5
+// are never candidates on the wheel, so they're excluded here).
+func wheelBasePrimes(n int) []int {
+	limit := int(math.Sqrt(float64(n))) + 1
+	all := SieveOfEratosthenes(limit)
+	base := make([]int, 0, len(all))
+	for _, p := range all {
+		if p > 5 {
+			base = append(base, p)
+		}
+	}
+	return base
+}
+
+// markWheelSegment resets buf and crosses off composite wheel positions in
+// [low, high). buf is indexed by (block-base)*8 + residueIdx, where block =
+// v/30 and residueIdx = wheelIndex30[v%30]. It returns base (the block
+// number buf[0:8] represents) and segLen (the number of valid entries in
+// buf, i.e. numBlocks*8).
+func markWheelSegment(low, high int, basePrimes []int, buf []byte) (base, segLen int) {
+	if high <= low {
+		return low / 30, 0
+	}
+
+	base = low / 30
+	highBlock := (high + 29) / 30
+	segLen = (highBlock - base) * 8
+
+	for i := 0; i < segLen; i++ {
+		buf[i] = 1
+	}
+
+	for _, p := range basePrimes {
+		start := p * p
+		if start < low {
+			start = ((low + p - 1) / p) * p
+		}
+
+		// Find the first k >= ceil(start/p) such that k is itself coprime to
+		// 30 -- since p is coprime to 30, p*k is then coprime to 30 too, and
+		// wheelGaps30 lets us walk every such k from there without retesting.
+		// kIdx tracks k's own position in the wheel so wheelGaps30[kIdx] gives
+		// the right stride to the next valid k; it does NOT track val's
+		// residue (p*k's residue is a different permutation of the wheel).
+		k := (start + p - 1) / p
+		kIdx := wheelIndex30[k%30]
+		for kIdx == -1 {
+			k++
+			kIdx = wheelIndex30[k%30]
+		}
+
+		val := p * k
+		for val < high {
+			if val >= low {
+				pos := (val/30-base)*8 + wheelIndex30[val%30]
+				buf[pos] = 0
+			}
+			val += p * wheelGaps30[kIdx]
+			kIdx = (kIdx + 1) % 8
+		}
+	}
+
+	return base, segLen
+}
+
+// sieveWheelSegment processes a single segment of a mod-30 wheel sieve,
+// mirroring sieveSegmentOddOnly but over 8 residues per 30 integers rather
+// than 1 per 2. Primes 2, 3, and 5 are never produced here -- callers emit
+// those directly, as with the odd-only sieve's handling of 2.
+func sieveWheelSegment(low, high int, basePrimes []int, buf []byte) []int {
+	var primes []int
+
+	base, segLen := markWheelSegment(low, high, basePrimes, buf)
+	for i := 0; i < segLen; i++ {
+		if buf[i] == 0 {
+			continue
+		}
+		v := (base+i/8)*30 + wheelResidues30[i%8]
+		if v >= low && v < high && v > 5 {
+			primes = append(primes, v)
+		}
+	}
+
+	return primes
+}
+
+// wheelBufLen returns a buffer capacity large enough for any segment of at
+// most segmentSize integers, regardless of its alignment within a 30-block.
+func wheelBufLen(segmentSize int) int {
+	return (segmentSize/30 + 2) * 8
+}
+
+// WheelSegmentedSieve sieves primes below n using a mod-30 wheel: only the 8
+// residues per 30 integers that are coprime to 2*3*5 are stored and tested,
+// cutting both memory and crossing-off work relative to SegmentedSieve's
+// odd-only (mod-2) approach. ctx is honored between segments, like
+// segmentedSieveCtx.
+func WheelSegmentedSieve(ctx context.Context, n int, segmentSize int, progress func(int)) []int {
+	if n <= 2 {
+		return nil
+	}
+	if segmentSize <= 0 {
+		segmentSize = DefaultSegmentSize
+	}
+
+	var primes []int
+	if n > 2 {
+		primes = append(primes, 2)
+	}
+	if n > 3 {
+		primes = append(primes, 3)
+	}
+	if n > 5 {
+		primes = append(primes, 5)
+	}
+
+	basePrimes := wheelBasePrimes(n)
+	buf := make([]byte, wheelBufLen(segmentSize))
+
+	segments := (n + segmentSize - 1) / segmentSize
+	for segIdx := 0; segIdx < segments; segIdx++ {
+		select {
+		case <-ctx.Done():
+			return primes
+		default:
+		}
+
+		low := segIdx * segmentSize
+		high := low + segmentSize
+		if high > n {
+			high = n
+		}
+
+		if high > 5 {
+			primes = append(primes, sieveWheelSegment(low, high, basePrimes, buf)...)
+		}
+
+		if progress != nil {
+			progress(1)
+		}
+	}
+
+	return primes
+}
+
+type wheelSegmentWork struct {
+	segIdx    int
+	low, high int
+}
+
+type wheelSegmentResult struct {
+	segIdx int
+	primes []int
+}
+
+func wheelWorkerProcessSegment(
+	ctx context.Context,
+	workChan <-chan wheelSegmentWork,
+	resultsChan chan<- wheelSegmentResult,
+	basePrimes []int,
+	segmentSize int,
+	wg *sync.WaitGroup,
+	completedSegments *int64,
+) {
+	defer wg.Done()
+	buf := make([]byte, wheelBufLen(segmentSize))
+
+	for {
+		var work wheelSegmentWork
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return
+		case work, ok = <-workChan:
+			if !ok {
+				return
+			}
+		}
+
+		var segPrimes []int
+		if work.high > 5 {
+			segPrimes = sieveWheelSegment(work.low, work.high, basePrimes, buf)
+		}
+		atomic.AddInt64(completedSegments, 1)
+
+		resultsChan <- wheelSegmentResult{segIdx: work.segIdx, primes: segPrimes}
+	}
+}
+
+// ParallelWheelSegmentedSieve is WheelSegmentedSieve split across a worker
+// pool, reassembling segment results in order like ParallelSegmentedSieve.
+func ParallelWheelSegmentedSieve(ctx context.Context, n int, workers, segmentSize int, progress func(int)) []int {
+	if n <= 2 {
+		return nil
+	}
+	if segmentSize <= 0 {
+		segmentSize = DefaultSegmentSize
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	basePrimes := wheelBasePrimes(n)
+
+	segments := (n + segmentSize - 1) / segmentSize
+	numWorkers := workers
+	if numWorkers > segments {
+		numWorkers = segments
+	}
+
+	workChan := make(chan wheelSegmentWork, numWorkers*2)
+	resultsChan := make(chan wheelSegmentResult, numWorkers*2)
+	var wg sync.WaitGroup
+	var completedSegments int64
+
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go wheelWorkerProcessSegment(ctx, workChan, resultsChan, basePrimes, segmentSize, &wg, &completedSegments)
+	}
+
+	// Producer: enqueue all segments
+	go func() {
+		defer close(workChan)
+		for segIdx := 0; segIdx < segments; segIdx++ {
+			low := segIdx * segmentSize
+			high := low + segmentSize
+			if high > n {
+				high = n
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case workChan <- wheelSegmentWork{segIdx: segIdx, low: low, high: high}:
+			}
+		}
+	}()
+
+	// Closer: wait for all workers then close results
+	go func() {
+		wg.Wait()
+		close(resultsChan)
+	}()
+
+	// Progress monitor goroutine
+	var progressDone chan struct{}
+	if progress != nil {
+		progressDone = make(chan struct{})
+		go func() {
+			defer close(progressDone)
+			var lastSeen int64
+			for {
+				current := atomic.LoadInt64(&completedSegments)
+				if current > lastSeen {
+					delta := int(current - lastSeen)
+					progress(delta)
+					lastSeen = current
+				}
+				if current >= int64(segments) {
+					return
+				}
+				runtime.Gosched()
+			}
+		}()
+	}
+
+	// Collect results indexed by segment for ordered reassembly
+	results := make([][]int, segments)
+	totalPrimes := 0
+	for result := range resultsChan {
+		results[result.segIdx] = result.primes
+		totalPrimes += len(result.primes)
+	}
+
+	// Wait for progress monitor to finish
+	if progressDone != nil {
+		<-progressDone
+	}
+
+	primes := make([]int, 0, totalPrimes+3)
+	if n > 2 {
+		primes = append(primes, 2)
+	}
+	if n > 3 {
+		primes = append(primes, 3)
+	}
+	if n > 5 {
+		primes = append(primes, 5)
+	}
+	for _, segPrimes := range results {
+		primes = append(primes, segPrimes...)
+	}
+
+	return primes
+}
@@ -0,0 +1,53 @@
+package prime
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWheelSegmentedSieveMatchesSegmentedSieve(t *testing.T) {
+	tests := []int{10, 100, 1000, 100000}
+	for _, n := range tests {
+		want := SegmentedSieve(n, 10, nil)
+		got := WheelSegmentedSieve(context.Background(), n, 10, nil)
+
+		if len(got) != len(want) {
+			t.Fatalf("WheelSegmentedSieve(%d) produced %d primes, want %d", n, len(got), len(want))
+		}
+		for i, v := range want {
+			if got[i] != v {
+				t.Errorf("WheelSegmentedSieve(%d)[%d] = %d, want %d", n, i, got[i], v)
+			}
+		}
+	}
+}
+
+func TestParallelWheelSegmentedSieveMatchesSequential(t *testing.T) {
+	n := 200000
+	seq := WheelSegmentedSieve(context.Background(), n, 100, nil)
+	par := ParallelWheelSegmentedSieve(context.Background(), n, 2, 100, nil)
+
+	if len(seq) != len(par) {
+		t.Fatalf("ParallelWheelSegmentedSieve(%d) length = %d, want %d", n, len(par), len(seq))
+	}
+	for i, v := range seq {
+		if par[i] != v {
+			t.Errorf("ParallelWheelSegmentedSieve(%d)[%d] = %d, want %d", n, i, par[i], v)
+		}
+	}
+}
+
+func TestGenerateWithWheelOption(t *testing.T) {
+	n := DefaultSegmentSize
+	want := Generate(n)
+	got := Generate(n, WithWheel(30))
+
+	if len(got) != len(want) {
+		t.Fatalf("Generate(%d, WithWheel(30)) length = %d, want %d", n, len(got), len(want))
+	}
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("Generate(%d, WithWheel(30))[%d] = %d, want %d", n, i, got[i], v)
+		}
+	}
+}
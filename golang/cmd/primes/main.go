@@ -2,9 +2,11 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"runtime"
 	"strconv"
 	"strings"
@@ -21,6 +23,7 @@ var (
 	workers     int
 	segment     int
 	quiet       bool
+	wheel       bool
 )
 
 func init() {
@@ -30,6 +33,7 @@ func init() {
 	flag.BoolVar(&quiet, "quiet", false, "Only print count (no prime list)")
 	flag.IntVar(&workers, "workers", 0, "Number of worker goroutines (default: NumCPU)")
 	flag.IntVar(&segment, "segment", prime.DefaultSegmentSize, "Segment size for segmented sieve")
+	flag.BoolVar(&wheel, "wheel", true, "Use mod-210 wheel factorization for segmented sieves")
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Prime Number Generator\n\n")
 		fmt.Fprintf(os.Stderr, "Usage: %s [flags] [n]\n\n", os.Args[0])
@@ -40,6 +44,7 @@ func init() {
 		fmt.Fprintf(os.Stderr, "  %s 1000000 --progress        # With progress bar\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s 100000000 --parallel     # Parallel processing\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s 1000000000 --quiet       # Count only, no output\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s 1000000000 --wheel=false # Disable mod-210 wheel factorization\n", os.Args[0])
 	}
 }
 
@@ -104,13 +109,24 @@ func main() {
 		}
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	var primes []int
 	computeStart := time.Now()
 
 	if parallel {
-		primes = prime.ParallelSegmentedSieve(n, workerCount, segmentSizeForProgress, progressCallback)
+		if wheel {
+			primes = prime.ParallelWheelSegmentedSieve(ctx, n, workerCount, segmentSizeForProgress, progressCallback)
+		} else {
+			primes = prime.ParallelSegmentedSieve(ctx, n, workerCount, segmentSizeForProgress, progressCallback, nil)
+		}
 	} else if n >= prime.DefaultSegmentSize {
-		primes = prime.SegmentedSieve(n, segmentSizeForProgress, progressCallback)
+		if wheel {
+			primes = prime.WheelSegmentedSieve(ctx, n, segmentSizeForProgress, progressCallback)
+		} else {
+			primes = prime.SegmentedSieve(ctx, n, segmentSizeForProgress, progressCallback)
+		}
 	} else {
 		primes = prime.SieveOfEratosthenes(n)
 	}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pchuck/infinite-series/golang/prime"
+)
+
+var (
+	n     int
+	out   string
+	table string
+	query int
+)
+
+func init() {
+	flag.IntVar(&n, "n", 0, "Build a table of primes below n")
+	flag.StringVar(&out, "out", "", "Path to write the built table to")
+	flag.StringVar(&table, "table", "", "Path of an existing table to query")
+	flag.IntVar(&query, "query", -1, "Look up a specific prime in -table")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Prime Table Builder/Reader\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s -n 1000000000 -out primes.tbl        # Build once\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -table primes.tbl -query 999999937   # Reuse it\n", os.Args[0])
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	if out != "" {
+		if n <= 0 {
+			fmt.Fprintln(os.Stderr, "Error: -out requires -n > 0")
+			os.Exit(1)
+		}
+		if err := build(n, out); err != nil {
+			fmt.Fprintf(os.Stderr, "Error building table: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if table != "" {
+		if err := inspect(table, query); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading table: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if out == "" && table == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+}
+
+func build(n int, path string) error {
+	start := time.Now()
+	primes := prime.GeneratePrimes(n, n >= prime.ParallelThreshold, true, nil)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := prime.SavePrimes(f, primes); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %d primes < %d to %s in %.3fs\n", len(primes), n, path, time.Since(start).Seconds())
+	return nil
+}
+
+func inspect(path string, query int) error {
+	t, err := prime.OpenPrimeTable(path)
+	if err != nil {
+		return err
+	}
+	defer t.Close()
+
+	fmt.Printf("Table %s: %d primes\n", path, t.Len())
+
+	if query >= 0 {
+		idx, found := t.Search(query)
+		if found {
+			fmt.Printf("%d is prime (index %d)\n", query, idx)
+		} else {
+			fmt.Printf("%d is not in the table\n", query)
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package progress
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"strings"
@@ -17,6 +18,7 @@ type ProgressBar struct {
 	description string
 	mu          sync.Mutex
 	started     bool
+	writer      io.Writer
 }
 
 func NewProgressBar(total int64, description string) *ProgressBar {
@@ -25,9 +27,33 @@ func NewProgressBar(total int64, description string) *ProgressBar {
 		width:       40,
 		description: description,
 		startTime:   time.Now(),
+		writer:      os.Stderr,
 	}
 }
 
+// SetWriter redirects render output away from the default os.Stderr, which
+// is useful in tests that want to capture or silence progress output.
+func (p *ProgressBar) SetWriter(w io.Writer) {
+	p.mu.Lock()
+	p.writer = w
+	p.mu.Unlock()
+}
+
+// isTerminal reports whether w looks like an interactive terminal. Non-TTY
+// writers (files, pipes, buffers) get one line per update instead of a
+// carriage-return-driven in-place redraw.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 func (p *ProgressBar) Update(delta int64) {
 	p.mu.Lock()
 	p.completed += delta
@@ -62,10 +88,17 @@ func (p *ProgressBar) Finish() {
 	}
 	p.completed = p.total
 	p.render()
-	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(p.writerOrDefault())
 	p.mu.Unlock()
 }
 
+func (p *ProgressBar) writerOrDefault() io.Writer {
+	if p.writer == nil {
+		return os.Stderr
+	}
+	return p.writer
+}
+
 func (p *ProgressBar) GetCompleted() int64 {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -85,22 +118,41 @@ func (p *ProgressBar) render() {
 	filled := int(percent * float64(p.width))
 
 	elapsed := time.Since(p.startTime)
-	_ = elapsed
+	rate := float64(p.completed) / elapsed.Seconds()
+
+	eta := "?"
+	if rate > 0 && p.completed < p.total {
+		remaining := p.total - p.completed
+		eta = time.Duration(float64(remaining) / rate * float64(time.Second)).Round(time.Second).String()
+	} else if p.completed >= p.total {
+		eta = "0s"
+	}
 
-	fmt.Fprintf(os.Stderr, "\r%s: [%s%s] %3.0f%% | %d/%d",
+	w := p.writerOrDefault()
+	line := fmt.Sprintf("%s: [%s%s] %3.0f%% | %d/%d | %s elapsed | %.0f/s | ETA %s",
 		p.description,
 		strings.Repeat("=", filled),
 		strings.Repeat(" ", p.width-filled),
 		percent*100,
 		p.completed,
-		p.total)
+		p.total,
+		elapsed.Round(time.Second),
+		rate,
+		eta)
+
+	if isTerminal(w) {
+		fmt.Fprintf(w, "\r%s", line)
+	} else {
+		fmt.Fprintln(w, line)
+	}
 }
 
 type MultiProgress struct {
-	bars     []*ProgressBar
-	mu       sync.Mutex
-	active   int
-	maxDescr int
+	bars         []*ProgressBar
+	mu           sync.Mutex
+	active       int
+	maxDescr     int
+	renderedLines int
 }
 
 func NewMultiProgress() *MultiProgress {
@@ -144,14 +196,22 @@ func (m *MultiProgress) RemoveBar(bar *ProgressBar) {
 	}
 }
 
+// RenderAll redraws every bar. On a terminal, it moves the cursor back up
+// over the previous frame first so bars update in place instead of
+// scrolling; on a non-TTY writer each call just appends a new frame.
 func (m *MultiProgress) RenderAll() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if m.renderedLines > 0 && isTerminal(os.Stderr) {
+		fmt.Fprintf(os.Stderr, "\x1b[%dA", m.renderedLines)
+	}
+
 	for _, bar := range m.bars {
 		bar.render()
 		fmt.Fprintln(os.Stderr)
 	}
+	m.renderedLines = len(m.bars)
 }
 
 func GetCPUCount() int {
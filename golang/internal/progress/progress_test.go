@@ -0,0 +1,132 @@
+package progress
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestProgressBarSetWriterNonTerminal pins SetWriter/isTerminal's non-TTY
+// path: a bytes.Buffer isn't a terminal, so Update should append one
+// newline-terminated line rather than a carriage-return redraw.
+func TestProgressBarSetWriterNonTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	bar := NewProgressBar(10, "widgets")
+	bar.SetWriter(&buf)
+
+	bar.Update(5)
+
+	out := buf.String()
+	if !strings.Contains(out, "widgets") {
+		t.Errorf("Update output %q does not contain description", out)
+	}
+	if strings.Contains(out, "\r") {
+		t.Errorf("Update output %q to a non-terminal writer used a carriage return redraw", out)
+	}
+	if !strings.HasSuffix(out, "\n") {
+		t.Errorf("Update output %q does not end with a newline", out)
+	}
+}
+
+// TestIsTerminal checks isTerminal's char-device test against both a
+// non-*os.File writer and a real device file, since /dev/null reports
+// os.ModeCharDevice just like a tty would.
+func TestIsTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	if isTerminal(&buf) {
+		t.Error("isTerminal(bytes.Buffer) = true, want false")
+	}
+
+	f, err := os.Open("/dev/null")
+	if err != nil {
+		t.Skipf("/dev/null unavailable: %v", err)
+	}
+	defer f.Close()
+
+	if !isTerminal(f) {
+		t.Error("isTerminal(/dev/null) = false, want true")
+	}
+}
+
+// TestProgressBarETAAndRate pins the elapsed/rate/ETA line against a
+// completed bar, where Finish forces completed == total and ETA reports 0s.
+func TestProgressBarETAAndRate(t *testing.T) {
+	var buf bytes.Buffer
+	bar := NewProgressBar(100, "widgets")
+	bar.SetWriter(&buf)
+
+	bar.Finish()
+
+	out := buf.String()
+	if !strings.Contains(out, "100%") {
+		t.Errorf("Finish output %q does not report 100%%", out)
+	}
+	if !strings.Contains(out, "ETA 0s") {
+		t.Errorf("Finish output %q does not report ETA 0s", out)
+	}
+	if got := bar.GetCompleted(); got != 100 {
+		t.Errorf("GetCompleted() = %d, want 100", got)
+	}
+}
+
+// TestMultiProgressAddRemoveBar pins description padding/truncation and that
+// RemoveBar both drops the bar and decrements the active count.
+func TestMultiProgressAddRemoveBar(t *testing.T) {
+	mp := NewMultiProgress()
+
+	short := mp.AddBar(10, "short")
+	if len(short.description) != mp.maxDescr {
+		t.Errorf("short bar description %q has length %d, want %d", short.description, len(short.description), mp.maxDescr)
+	}
+
+	long := mp.AddBar(10, strings.Repeat("x", mp.maxDescr+10))
+	if len(long.description) != mp.maxDescr {
+		t.Errorf("long bar description %q has length %d, want %d", long.description, len(long.description), mp.maxDescr)
+	}
+	if !strings.HasSuffix(long.description, "...") {
+		t.Errorf("truncated description %q does not end with ...", long.description)
+	}
+
+	if mp.active != 2 {
+		t.Fatalf("active = %d, want 2", mp.active)
+	}
+
+	mp.RemoveBar(short)
+	if mp.active != 1 {
+		t.Errorf("active after RemoveBar = %d, want 1", mp.active)
+	}
+	for _, b := range mp.bars {
+		if b == short {
+			t.Error("RemoveBar did not remove the bar from mp.bars")
+		}
+	}
+}
+
+// TestMultiProgressRenderAll checks that RenderAll renders every bar to its
+// own writer.
+func TestMultiProgressRenderAll(t *testing.T) {
+	mp := NewMultiProgress()
+
+	var buf1, buf2 bytes.Buffer
+	bar1 := mp.AddBar(10, "one")
+	bar1.SetWriter(&buf1)
+	bar2 := mp.AddBar(10, "two")
+	bar2.SetWriter(&buf2)
+
+	bar1.Update(5)
+	bar2.Update(2)
+	buf1.Reset()
+	buf2.Reset()
+	mp.RenderAll()
+
+	if buf1.Len() == 0 {
+		t.Error("RenderAll did not write to bar1's writer")
+	}
+	if buf2.Len() == 0 {
+		t.Error("RenderAll did not write to bar2's writer")
+	}
+	if mp.renderedLines != 2 {
+		t.Errorf("renderedLines = %d, want 2", mp.renderedLines)
+	}
+}
@@ -0,0 +1,70 @@
+package prime
+
+import "testing"
+
+// TestPrimeIteratorMatchesSieve pins PrimeIterator's one-at-a-time streaming
+// output against SieveOfEratosthenes over the same bound.
+func TestPrimeIteratorMatchesSieve(t *testing.T) {
+	n := 20000
+	want := SieveOfEratosthenes(n)
+
+	it := NewPrimeIterator(n, 500, nil)
+	var got []int64
+	for {
+		p, ok := it.Next()
+		if !ok {
+			break
+		}
+		got = append(got, p)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("PrimeIterator produced %d primes, want %d", len(got), len(want))
+	}
+	for i, v := range want {
+		if got[i] != int64(v) {
+			t.Errorf("PrimeIterator[%d] = %d, want %d", i, got[i], v)
+		}
+	}
+}
+
+// TestPrimeIteratorEmptyBelowTwo checks the iterator is immediately
+// exhausted for n <= 2, the same edge case PrimesInRange/SieveOfEratosthenes
+// special-case.
+func TestPrimeIteratorEmptyBelowTwo(t *testing.T) {
+	it := NewPrimeIterator(2, 50, nil)
+	if _, ok := it.Next(); ok {
+		t.Error("NewPrimeIterator(2, ...) produced a prime, want none")
+	}
+}
+
+// TestParallelPrimeBatchesMatchesSieve pins ParallelPrimeBatches' ordered,
+// segment-sized batches against SieveOfEratosthenes, flattening the batches
+// back into a single slice for comparison.
+func TestParallelPrimeBatchesMatchesSieve(t *testing.T) {
+	n := 20000
+	want := SieveOfEratosthenes(n)
+
+	var got []int64
+	for batch := range ParallelPrimeBatches(n, 4, 500, nil) {
+		got = append(got, batch...)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("ParallelPrimeBatches produced %d primes, want %d", len(got), len(want))
+	}
+	for i, v := range want {
+		if got[i] != int64(v) {
+			t.Errorf("ParallelPrimeBatches[%d] = %d, want %d", i, got[i], v)
+		}
+	}
+}
+
+// TestParallelPrimeBatchesEmptyBelowTwo checks the returned channel is
+// closed immediately for n <= 2.
+func TestParallelPrimeBatchesEmptyBelowTwo(t *testing.T) {
+	batches := ParallelPrimeBatches(2, 2, 50, nil)
+	if batch, ok := <-batches; ok {
+		t.Errorf("ParallelPrimeBatches(2, ...) produced batch %v, want none", batch)
+	}
+}
@@ -2,10 +2,17 @@ package prime
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"math"
+	"math/big"
+	"math/bits"
+	"net"
+	"net/http"
 	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 const (
@@ -42,7 +49,10 @@ func SieveOfEratosthenes(n int) []int {
 	return primes
 }
 
-func SegmentedSieve(n int, segmentSize int, progress func(int)) []int {
+// SegmentedSieve sieves [0, n) in fixed-size segments. ctx is checked
+// between segments so a long-running sieve can be cancelled; on
+// cancellation the primes found so far are returned.
+func SegmentedSieve(ctx context.Context, n int, segmentSize int, progress func(int)) []int {
 	if n <= 2 {
 		return nil
 	}
@@ -60,6 +70,12 @@ func SegmentedSieve(n int, segmentSize int, progress func(int)) []int {
 	isPrime := make([]byte, segmentSize)
 
 	for segIdx := 0; segIdx < segments; segIdx++ {
+		select {
+		case <-ctx.Done():
+			return primes
+		default:
+		}
+
 		low := segIdx * segmentSize
 		high := low + segmentSize
 		if high > n {
@@ -122,15 +138,38 @@ type segmentResult struct {
 	primes []int
 }
 
+// workerProcessSegment crosses off composites for segments pulled from
+// workChan. workerID and tracker are for the optional metrics path added in
+// chunk0-5: when tracker is non-nil, each segment's buffer-pool hit/miss and
+// primes-found count are added to the tracker's totals, and its time spent
+// sieving is recorded against workerID (see ProgressTracker.ServeMetrics).
+// Passing a nil tracker costs one nil check per segment and otherwise
+// behaves exactly as before.
 func workerProcessSegment(
+	ctx context.Context,
 	workChan <-chan segmentWork,
 	resultsChan chan<- segmentResult,
 	basePrimes []int,
 	bufferPool *sync.Pool,
 	wg *sync.WaitGroup,
+	workerID int,
+	tracker *ProgressTracker,
 ) {
 	defer wg.Done()
-	for work := range workChan {
+	for {
+		var work segmentWork
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return
+		case work, ok = <-workChan:
+			if !ok {
+				return
+			}
+		}
+
+		busyStart := time.Now()
+
 		// Get buffer from pool or allocate new one
 		var isPrime []byte
 		if buf := bufferPool.Get(); buf != nil {
@@ -140,8 +179,20 @@ func workerProcessSegment(
 			} else {
 				isPrime = isPrime[:work.segLen]
 			}
+			if tracker != nil {
+				// bufferPool.New hands out zero-length buffers; a reused one
+				// comes back with its previous segment's length.
+				if len(buf.([]byte)) == 0 {
+					tracker.AddBufferMiss()
+				} else {
+					tracker.AddBufferHit()
+				}
+			}
 		} else {
 			isPrime = make([]byte, work.segLen)
+			if tracker != nil {
+				tracker.AddBufferMiss()
+			}
 		}
 
 		// Reset buffer to all 1s
@@ -174,6 +225,11 @@ func workerProcessSegment(
 		// Return buffer to pool for reuse
 		bufferPool.Put(isPrime)
 
+		if tracker != nil {
+			tracker.AddPrimesFound(int64(len(primes)))
+			tracker.AddWorkerBusy(workerID, time.Since(busyStart))
+		}
+
 		resultsChan <- segmentResult{
 			segIdx: work.segIdx,
 			primes: primes,
@@ -181,7 +237,14 @@ func workerProcessSegment(
 	}
 }
 
-func ParallelSegmentedSieve(n int, workers, segmentSize int, progress func(int)) []int {
+// ParallelSegmentedSieve is the parallel counterpart to SegmentedSieve. ctx
+// is honored both by the producer (stops enqueuing new segments) and by each
+// worker (stops picking up queued segments), so a run over billions can be
+// aborted cleanly instead of draining the whole queue first. tracker, if
+// non-nil, is fed live counters (totals for segments completed, primes found,
+// and buffer-pool hits/misses, plus busy time per worker) so ServeMetrics
+// reports live numbers instead of permanent zeroes; pass nil to opt out.
+func ParallelSegmentedSieve(ctx context.Context, n int, workers, segmentSize int, progress func(int), tracker *ProgressTracker) []int {
 	if n <= 2 {
 		return nil
 	}
@@ -214,10 +277,11 @@ func ParallelSegmentedSieve(n int, workers, segmentSize int, progress func(int))
 
 	for i := 0; i < numWorkers; i++ {
 		wg.Add(1)
-		go workerProcessSegment(workChan, resultsChan, basePrimes, bufferPool, &wg)
+		go workerProcessSegment(ctx, workChan, resultsChan, basePrimes, bufferPool, &wg, i, tracker)
 	}
 
 	go func() {
+		defer close(workChan)
 		for segIdx := 0; segIdx < segments; segIdx++ {
 			low := segIdx * segmentSize
 			high := low + segmentSize
@@ -238,15 +302,18 @@ func ParallelSegmentedSieve(n int, workers, segmentSize int, progress func(int))
 			}
 			segLen := high - segmentLow
 
-			workChan <- segmentWork{
+			select {
+			case <-ctx.Done():
+				return
+			case workChan <- segmentWork{
 				segIdx:     segIdx,
 				low:        low,
 				high:       high,
 				segmentLow: segmentLow,
 				segLen:     segLen,
+			}:
 			}
 		}
-		close(workChan)
 	}()
 
 	go func() {
@@ -258,6 +325,9 @@ func ParallelSegmentedSieve(n int, workers, segmentSize int, progress func(int))
 	results := make([][]int, segments)
 	for result := range resultsChan {
 		results[result.segIdx] = result.primes
+		if tracker != nil {
+			tracker.AddCompleted(1)
+		}
 	}
 
 	// Calculate total primes for capacity
@@ -276,31 +346,913 @@ func ParallelSegmentedSieve(n int, workers, segmentSize int, progress func(int))
 	return allPrimes
 }
 
-func GeneratePrimes(n int, parallel bool, progress func(int)) []int {
+// rangeWordsFor returns the number of uint64 words needed to hold segLen
+// one-bit-per-odd-candidate entries.
+func rangeWordsFor(segLen int) int {
+	return (segLen + 63) / 64
+}
+
+// resetRangeWords sets the first segLen bits to 1 (candidate prime) and
+// clears any unused trailing bits in the final word.
+func resetRangeWords(words []uint64, segLen int) {
+	nw := rangeWordsFor(segLen)
+	for i := 0; i < nw; i++ {
+		words[i] = ^uint64(0)
+	}
+	if rem := segLen % 64; rem != 0 {
+		words[nw-1] &= (uint64(1) << uint(rem)) - 1
+	}
+}
+
+// clearRangeMultiples clears bits start, start+step, start+2*step, ... < segLen.
+func clearRangeMultiples(words []uint64, start, segLen, step int) {
+	for j := start; j < segLen; j += step {
+		words[j/64] &^= 1 << uint(j%64)
+	}
+}
+
+// extractRangePrimes appends the numbers represented by set bits in words
+// (bit i == segLow+2*i) to out, stopping once segLen bits have been scanned.
+func extractRangePrimes(words []uint64, segLen int, segLow int64, out []int64) []int64 {
+	nw := rangeWordsFor(segLen)
+	for w := 0; w < nw; w++ {
+		word := words[w]
+		for word != 0 {
+			idx := w*64 + bits.TrailingZeros64(word)
+			if idx >= segLen {
+				break
+			}
+			out = append(out, segLow+2*int64(idx))
+			word &= word - 1
+		}
+	}
+	return out
+}
+
+// sieveRangeSegment bit-sieves the odd numbers in [segLow, segHigh) against
+// basePrimes (odd primes up to sqrt(hi)), writing into the reusable words
+// buffer, and returns the primes found.
+func sieveRangeSegment(segLow, segHigh int64, basePrimes []int, words []uint64) []int64 {
+	segLen := int((segHigh - segLow + 1) / 2)
+	if segLen <= 0 {
+		return nil
+	}
+
+	resetRangeWords(words, segLen)
+
+	for _, p := range basePrimes {
+		if p <= 2 {
+			continue
+		}
+		p64 := int64(p)
+		start := p64 * p64
+		if start < segLow {
+			start = ((segLow + p64 - 1) / p64) * p64
+		}
+		if start%2 == 0 {
+			start += p64
+		}
+		if start >= segHigh {
+			continue
+		}
+
+		idx := int((start - segLow) / 2)
+		clearRangeMultiples(words, idx, segLen, p)
+	}
+
+	return extractRangePrimes(words, segLen, segLow, nil)
+}
+
+// PrimesInRange sieves only [lo, hi) using base primes up to sqrt(hi), so
+// callers can generate primes near large bounds (e.g. 10^12) without first
+// sieving from zero. The segment buffer is bit-packed (one bit per odd
+// candidate), using about 1/16 the memory per integer of SegmentedSieve's
+// one-byte-per-integer buffer.
+// progress, if non-nil, is called with the number of segments completed so far.
+func PrimesInRange(lo, hi int64, segmentSize int, progress func(int)) []int64 {
+	if hi <= 2 || hi <= lo {
+		return nil
+	}
+	if lo < 2 {
+		lo = 2
+	}
+	if segmentSize <= 0 {
+		segmentSize = DefaultSegmentSize
+	}
+
+	baseLimit := int(math.Sqrt(float64(hi)))
+	basePrimes := SieveOfEratosthenes(baseLimit + 1)
+
+	var primes []int64
+	if lo <= 2 && hi > 2 {
+		primes = append(primes, 2)
+	}
+
+	segLow := lo
+	if segLow < 3 {
+		segLow = 3
+	}
+	if segLow%2 == 0 {
+		segLow++
+	}
+
+	words := make([]uint64, rangeWordsFor(2*segmentSize))
+
+	segIdx := 0
+	for segLow < hi {
+		segHigh := segLow + int64(2*segmentSize)
+		if segHigh > hi {
+			segHigh = hi
+		}
+
+		primes = append(primes, sieveRangeSegment(segLow, segHigh, basePrimes, words)...)
+
+		segIdx++
+		if progress != nil {
+			progress(segIdx)
+		}
+
+		segLow = segHigh
+	}
+
+	return primes
+}
+
+type rangeSegmentWork struct {
+	segIdx  int
+	segLow  int64
+	segHigh int64
+}
+
+type rangeSegmentResult struct {
+	segIdx int
+	primes []int64
+}
+
+func rangeWorkerProcessSegment(
+	workChan <-chan rangeSegmentWork,
+	resultsChan chan<- rangeSegmentResult,
+	basePrimes []int,
+	segmentSize int,
+	wg *sync.WaitGroup,
+) {
+	defer wg.Done()
+	words := make([]uint64, rangeWordsFor(2*segmentSize))
+	for work := range workChan {
+		primes := sieveRangeSegment(work.segLow, work.segHigh, basePrimes, words)
+		resultsChan <- rangeSegmentResult{segIdx: work.segIdx, primes: primes}
+	}
+}
+
+// ParallelPrimesInRange is the parallel counterpart to PrimesInRange: it
+// partitions [lo, hi) into segments processed by a worker pool, then
+// reassembles the results in order.
+func ParallelPrimesInRange(lo, hi int64, workers, segmentSize int, progress func(int)) []int64 {
+	if hi <= 2 || hi <= lo {
+		return nil
+	}
+	if lo < 2 {
+		lo = 2
+	}
+	if segmentSize <= 0 {
+		segmentSize = DefaultSegmentSize
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	baseLimit := int(math.Sqrt(float64(hi)))
+	basePrimes := SieveOfEratosthenes(baseLimit + 1)
+
+	segLow := lo
+	if segLow < 3 {
+		segLow = 3
+	}
+	if segLow%2 == 0 {
+		segLow++
+	}
+
+	var bounds []rangeSegmentWork
+	for i, low := 0, segLow; low < hi; i++ {
+		high := low + int64(2*segmentSize)
+		if high > hi {
+			high = hi
+		}
+		bounds = append(bounds, rangeSegmentWork{segIdx: i, segLow: low, segHigh: high})
+		low = high
+	}
+
+	numWorkers := workers
+	if numWorkers > len(bounds) {
+		numWorkers = len(bounds)
+	}
+	if numWorkers <= 0 {
+		numWorkers = 1
+	}
+
+	workChan := make(chan rangeSegmentWork, len(bounds))
+	resultsChan := make(chan rangeSegmentResult, len(bounds))
+	var wg sync.WaitGroup
+
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go rangeWorkerProcessSegment(workChan, resultsChan, basePrimes, segmentSize, &wg)
+	}
+
+	for _, work := range bounds {
+		workChan <- work
+	}
+	close(workChan)
+
+	go func() {
+		wg.Wait()
+		close(resultsChan)
+	}()
+
+	results := make([][]int64, len(bounds))
+	completed := 0
+	for result := range resultsChan {
+		results[result.segIdx] = result.primes
+		completed++
+		if progress != nil {
+			progress(completed)
+		}
+	}
+
+	var primes []int64
+	if lo <= 2 && hi > 2 {
+		primes = append(primes, 2)
+	}
+	for _, segPrimes := range results {
+		primes = append(primes, segPrimes...)
+	}
+
+	return primes
+}
+
+// PrimeIterator streams primes below a bound one at a time, so only a
+// single segment's worth of memory is live at any point rather than the
+// full result slice that GeneratePrimes materializes.
+type PrimeIterator struct {
+	hi         int64
+	segmentSize int
+	basePrimes []int
+	words      []uint64
+	buf        []int64
+	pos        int
+	segLow     int64
+	segIdx     int
+	progress   func(int)
+	includeTwo bool
+}
+
+// NewPrimeIterator builds an iterator over the primes in [0, n).
+func NewPrimeIterator(n int, segmentSize int, progress func(int)) *PrimeIterator {
+	if segmentSize <= 0 {
+		segmentSize = DefaultSegmentSize
+	}
+
+	it := &PrimeIterator{
+		hi:          int64(n),
+		segmentSize: segmentSize,
+		progress:    progress,
+	}
+
+	if it.hi > 2 {
+		baseLimit := int(math.Sqrt(float64(it.hi)))
+		it.basePrimes = SieveOfEratosthenes(baseLimit + 1)
+		it.words = make([]uint64, rangeWordsFor(2*segmentSize))
+		it.segLow = 3
+		it.includeTwo = true
+	} else {
+		it.segLow = it.hi
+	}
+
+	return it
+}
+
+// Next returns the next prime and true, or (0, false) once exhausted.
+func (it *PrimeIterator) Next() (int64, bool) {
+	if it.includeTwo {
+		it.includeTwo = false
+		return 2, true
+	}
+
+	for it.pos >= len(it.buf) {
+		if it.segLow >= it.hi {
+			return 0, false
+		}
+
+		segHigh := it.segLow + int64(2*it.segmentSize)
+		if segHigh > it.hi {
+			segHigh = it.hi
+		}
+
+		it.buf = sieveRangeSegment(it.segLow, segHigh, it.basePrimes, it.words)
+		it.pos = 0
+		it.segLow = segHigh
+		it.segIdx++
+		if it.progress != nil {
+			it.progress(it.segIdx)
+		}
+	}
+
+	v := it.buf[it.pos]
+	it.pos++
+	return v, true
+}
+
+// ParallelPrimeBatches sieves primes below n with a worker pool and streams
+// them as ordered, segment-sized []int64 batches on the returned channel, so
+// a consumer can fold over the results without the workers blocking on a
+// single materialized slice. The channel is closed once all batches have
+// been sent.
+func ParallelPrimeBatches(n int, workers, segmentSize int, progress func(int)) <-chan []int64 {
+	out := make(chan []int64)
+	if n <= 2 {
+		close(out)
+		return out
+	}
+	if segmentSize <= 0 {
+		segmentSize = DefaultSegmentSize
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	hi := int64(n)
+	baseLimit := int(math.Sqrt(float64(hi)))
+	basePrimes := SieveOfEratosthenes(baseLimit + 1)
+
+	var bounds []rangeSegmentWork
+	for i, low := 0, int64(3); low < hi; i++ {
+		high := low + int64(2*segmentSize)
+		if high > hi {
+			high = hi
+		}
+		bounds = append(bounds, rangeSegmentWork{segIdx: i, segLow: low, segHigh: high})
+		low = high
+	}
+
+	numWorkers := workers
+	if numWorkers > len(bounds) {
+		numWorkers = len(bounds)
+	}
+	if numWorkers <= 0 {
+		numWorkers = 1
+	}
+
+	workChan := make(chan rangeSegmentWork, len(bounds))
+	resultsChan := make(chan rangeSegmentResult, len(bounds))
+	var wg sync.WaitGroup
+
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go rangeWorkerProcessSegment(workChan, resultsChan, basePrimes, segmentSize, &wg)
+	}
+
+	for _, work := range bounds {
+		workChan <- work
+	}
+	close(workChan)
+
+	go func() {
+		wg.Wait()
+		close(resultsChan)
+	}()
+
+	go func() {
+		defer close(out)
+		out <- []int64{2}
+
+		pending := make(map[int][]int64)
+		next := 0
+		completed := 0
+		for result := range resultsChan {
+			pending[result.segIdx] = result.primes
+			completed++
+			if progress != nil {
+				progress(completed)
+			}
+
+			for {
+				batch, ok := pending[next]
+				if !ok {
+					break
+				}
+				out <- batch
+				delete(pending, next)
+				next++
+			}
+		}
+	}()
+
+	return out
+}
+
+// wheel210Residues lists, in ascending order, the 48 residues mod 210 that
+// are coprime to 2*3*5*7=210. A segment sieved with the wheel only stores
+// one byte per (block, residue) pair instead of one byte per integer.
+var wheel210Residues = [48]int{
+	1, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47,
+	53, 59, 61, 67, 71, 73, 79, 83, 89, 97, 101, 103,
+	107, 109, 113, 121, 127, 131, 137, 139, 143, 149, 151, 157,
+	163, 167, 169, 173, 179, 181, 187, 191, 193, 197, 199, 209,
+}
+
+// wheel210Index maps a residue mod 210 to its position in wheel210Residues,
+// or -1 if the residue isn't coprime to 210.
+var wheel210Index [210]int
+
+// wheel210Gaps[i] is the distance from the wheel residue at index i to the one
+// at (i+1)%48. sieveWheelSegment steps the multiplier k = v/p by these gaps,
+// indexed by k's own wheel position (not v's, whose residues are a
+// prime-dependent permutation of the wheel), so this table is prime-independent.
+var wheel210Gaps [48]int
+
+func init() {
+	for i := range wheel210Index {
+		wheel210Index[i] = -1
+	}
+	for i, r := range wheel210Residues {
+		wheel210Index[r] = i
+	}
+	for i := range wheel210Gaps {
+		next := (i + 1) % 48
+		gap := wheel210Residues[next] - wheel210Residues[i]
+		if next == 0 {
+			gap = 210 - wheel210Residues[47] + wheel210Residues[0]
+		}
+		wheel210Gaps[i] = gap
+	}
+}
+
+// wheelBasePrimes returns the odd primes up to sqrt(n) that are not 2, 3, 5,
+// or 7, since those four are emitted directly by the wheel sieve.
+func wheelBasePrimes(n int) []int {
+	baseLimit := int(math.Sqrt(float64(n)))
+	all := SieveOfEratosthenes(baseLimit + 1)
+	basePrimes := make([]int, 0, len(all))
+	for _, p := range all {
+		if p >= 11 {
+			basePrimes = append(basePrimes, p)
+		}
+	}
+	return basePrimes
+}
+
+// sieveWheelSegment wheel-210 sieves the block range [segBase, segHigh)
+// (segBase a multiple of 210) into buf, indexed by block*48+residueIdx, and
+// returns the primes found.
+func sieveWheelSegment(segBase, segHigh int, basePrimes []int, buf []byte) []int {
+	nblocks := (segHigh - segBase + 209) / 210
+	segLen := nblocks * 48
+	for i := 0; i < segLen; i++ {
+		buf[i] = 1
+	}
+	if segBase == 0 {
+		buf[wheel210Index[1]] = 0 // 1 is coprime to 210 but not prime
+	}
+
+	for _, p := range basePrimes {
+		start := p * p
+		if start < segBase {
+			start = ((segBase + p - 1) / p) * p
+		}
+		for start < segHigh && wheel210Index[start%210] < 0 {
+			start += p
+		}
+		if start >= segHigh {
+			continue
+		}
+
+		// kIdx tracks the multiplier k = v/p's own position in the wheel, so
+		// wheel210Gaps[kIdx] gives the right stride to the next k coprime to
+		// 210 -- v's own residue (v = p*k) is a different permutation of the
+		// wheel and must be looked up fresh for each v to find its buf slot.
+		v := start
+		kIdx := wheel210Index[(v/p)%210]
+		for v < segHigh {
+			pos := (v-segBase)/210*48 + wheel210Index[v%210]
+			if pos < segLen {
+				buf[pos] = 0
+			}
+			v += p * wheel210Gaps[kIdx]
+			kIdx = (kIdx + 1) % 48
+		}
+	}
+
+	primes := make([]int, 0, segLen/4)
+	for block := 0; block < nblocks; block++ {
+		blockBase := segBase + block*210
+		for i := 0; i < 48; i++ {
+			if buf[block*48+i] == 1 {
+				val := blockBase + wheel210Residues[i]
+				if val < segHigh {
+					primes = append(primes, val)
+				}
+			}
+		}
+	}
+
+	return primes
+}
+
+// WheelSegmentedSieve is a wheel-2-3-5-7 (mod 210) variant of SegmentedSieve:
+// only the 48 residues per 210 integers that are coprime to 210 are stored,
+// cutting segment memory ~4.4x and skipping crossing-off work for 2, 3, 5,
+// and 7 entirely. 2, 3, 5, and 7 are emitted directly. ctx is honored between
+// segments, same as SegmentedSieve.
+func WheelSegmentedSieve(ctx context.Context, n int, segmentSize int, progress func(int)) []int {
+	if n <= 2 {
+		return nil
+	}
+	if segmentSize <= 0 {
+		segmentSize = DefaultSegmentSize
+	}
+
+	basePrimes := wheelBasePrimes(n)
+
+	primes := make([]int, 0, n/int(math.Log(float64(n))))
+	for _, p := range [4]int{2, 3, 5, 7} {
+		if p < n {
+			primes = append(primes, p)
+		}
+	}
+
+	blocksPerSegment := (segmentSize + 209) / 210
+	if blocksPerSegment < 1 {
+		blocksPerSegment = 1
+	}
+	buf := make([]byte, blocksPerSegment*48)
+
+	segIdx := 0
+	for segBase := 0; segBase < n; segBase += blocksPerSegment * 210 {
+		select {
+		case <-ctx.Done():
+			return primes
+		default:
+		}
+
+		segHigh := segBase + blocksPerSegment*210
+		if segHigh > n {
+			segHigh = n
+		}
+
+		primes = append(primes, sieveWheelSegment(segBase, segHigh, basePrimes, buf)...)
+
+		segIdx++
+		if progress != nil {
+			progress(segIdx)
+		}
+	}
+
+	return primes
+}
+
+type wheelSegmentWork struct {
+	segIdx  int
+	segBase int
+	segHigh int
+}
+
+type wheelSegmentResult struct {
+	segIdx int
+	primes []int
+}
+
+func wheelWorkerProcessSegment(
+	ctx context.Context,
+	workChan <-chan wheelSegmentWork,
+	resultsChan chan<- wheelSegmentResult,
+	basePrimes []int,
+	bufferPool *sync.Pool,
+	wg *sync.WaitGroup,
+) {
+	defer wg.Done()
+	for {
+		var work wheelSegmentWork
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return
+		case work, ok = <-workChan:
+			if !ok {
+				return
+			}
+		}
+
+		nblocks := (work.segHigh - work.segBase + 209) / 210
+		segLen := nblocks * 48
+
+		var buf []byte
+		if b := bufferPool.Get(); b != nil {
+			buf = b.([]byte)
+			if cap(buf) < segLen {
+				buf = make([]byte, segLen)
+			} else {
+				buf = buf[:segLen]
+			}
+		} else {
+			buf = make([]byte, segLen)
+		}
+
+		primes := sieveWheelSegment(work.segBase, work.segHigh, basePrimes, buf)
+		bufferPool.Put(buf)
+
+		resultsChan <- wheelSegmentResult{segIdx: work.segIdx, primes: primes}
+	}
+}
+
+// ParallelWheelSegmentedSieve is the parallel counterpart to
+// WheelSegmentedSieve. ctx is honored both by the producer (stops enqueuing
+// new segments) and by each worker (stops picking up queued segments),
+// mirroring ParallelSegmentedSieve.
+func ParallelWheelSegmentedSieve(ctx context.Context, n, workers, segmentSize int, progress func(int)) []int {
+	if n <= 2 {
+		return nil
+	}
+	if segmentSize <= 0 {
+		segmentSize = DefaultSegmentSize
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	basePrimes := wheelBasePrimes(n)
+
+	blocksPerSegment := (segmentSize + 209) / 210
+	if blocksPerSegment < 1 {
+		blocksPerSegment = 1
+	}
+	segStride := blocksPerSegment * 210
+
+	var bounds []wheelSegmentWork
+	for i, base := 0, 0; base < n; i++ {
+		high := base + segStride
+		if high > n {
+			high = n
+		}
+		bounds = append(bounds, wheelSegmentWork{segIdx: i, segBase: base, segHigh: high})
+		base = high
+	}
+
+	numWorkers := workers
+	if numWorkers > len(bounds) {
+		numWorkers = len(bounds)
+	}
+	if numWorkers <= 0 {
+		numWorkers = 1
+	}
+
+	workChan := make(chan wheelSegmentWork, len(bounds))
+	resultsChan := make(chan wheelSegmentResult, len(bounds))
+	var wg sync.WaitGroup
+	bufferPool := &sync.Pool{
+		New: func() interface{} {
+			return make([]byte, 0, blocksPerSegment*48)
+		},
+	}
+
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go wheelWorkerProcessSegment(ctx, workChan, resultsChan, basePrimes, bufferPool, &wg)
+	}
+
+	go func() {
+		defer close(workChan)
+		for _, work := range bounds {
+			select {
+			case <-ctx.Done():
+				return
+			case workChan <- work:
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultsChan)
+	}()
+
+	results := make([][]int, len(bounds))
+	completed := 0
+	for result := range resultsChan {
+		results[result.segIdx] = result.primes
+		completed++
+		if progress != nil {
+			progress(completed)
+		}
+	}
+
+	primes := make([]int, 0, n/int(math.Log(float64(n))))
+	for _, p := range [4]int{2, 3, 5, 7} {
+		if p < n {
+			primes = append(primes, p)
+		}
+	}
+	for _, segPrimes := range results {
+		primes = append(primes, segPrimes...)
+	}
+
+	return primes
+}
+
+func collectFromIterator(n, segmentSize int, progress func(int)) []int {
+	estimated := n / int(math.Log(float64(n)))
+	primes := make([]int, 0, estimated)
+
+	it := NewPrimeIterator(n, segmentSize, progress)
+	for {
+		p, ok := it.Next()
+		if !ok {
+			break
+		}
+		primes = append(primes, int(p))
+	}
+
+	return primes
+}
+
+func collectFromBatches(n, workers, segmentSize int, progress func(int)) []int {
+	estimated := n / int(math.Log(float64(n)))
+	primes := make([]int, 0, estimated)
+
+	for batch := range ParallelPrimeBatches(n, workers, segmentSize, progress) {
+		for _, p := range batch {
+			primes = append(primes, int(p))
+		}
+	}
+
+	return primes
+}
+
+// GeneratePrimes materializes the full result slice for callers who don't
+// need to stream. wheel enables mod-210 wheel factorization for n >=
+// DefaultSegmentSize, which callers should default to true: with it set, such
+// n go through WheelSegmentedSieve / ParallelWheelSegmentedSieve; with it
+// unset, through PrimeIterator / ParallelPrimeBatches. Smaller n always use
+// SieveOfEratosthenes.
+func GeneratePrimes(n int, parallel bool, wheel bool, progress func(int)) []int {
 	if n <= 2 {
 		return nil
 	}
 
 	if parallel && n >= ParallelThreshold {
-		return ParallelSegmentedSieve(n, 0, DefaultSegmentSize, progress)
+		if wheel {
+			return ParallelWheelSegmentedSieve(context.Background(), n, 0, DefaultSegmentSize, progress)
+		}
+		return collectFromBatches(n, 0, DefaultSegmentSize, progress)
 	}
 
 	if n >= DefaultSegmentSize {
-		return SegmentedSieve(n, DefaultSegmentSize, progress)
+		if wheel {
+			return WheelSegmentedSieve(context.Background(), n, DefaultSegmentSize, progress)
+		}
+		return collectFromIterator(n, DefaultSegmentSize, progress)
 	}
 
 	return SieveOfEratosthenes(n)
 }
 
+// mrWitnesses are sufficient for deterministic Miller-Rabin over all of
+// uint64 (n < 318,665,857,834,031,151,167,461).
+var mrWitnesses = [...]uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// mrWitness reports whether a is a Miller-Rabin witness for the compositeness
+// of n, given n-1 = 2^s * d. Arithmetic runs through math/big to sidestep
+// uint64 overflow in the modular squaring.
+func mrWitness(n, d uint64, s int, a uint64) bool {
+	mod := new(big.Int).SetUint64(n)
+	nMinus1 := new(big.Int).Sub(mod, big.NewInt(1))
+
+	x := new(big.Int).Exp(new(big.Int).SetUint64(a), new(big.Int).SetUint64(d), mod)
+	if x.Cmp(big.NewInt(1)) == 0 || x.Cmp(nMinus1) == 0 {
+		return true
+	}
+
+	for i := 0; i < s-1; i++ {
+		x.Mul(x, x)
+		x.Mod(x, mod)
+		if x.Cmp(nMinus1) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsPrime is a deterministic Miller-Rabin primality test, correct for every
+// n representable in uint64.
+func IsPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range mrWitnesses {
+		if n == p {
+			return true
+		}
+		if n%p == 0 {
+			return false
+		}
+	}
+
+	d := n - 1
+	s := 0
+	for d%2 == 0 {
+		d /= 2
+		s++
+	}
+
+	for _, a := range mrWitnesses {
+		if !mrWitness(n, d, s, a) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsPrimeProbabilistic runs randomized Miller-Rabin (plus a Baillie-PSW
+// check) via math/big for n too large for IsPrime's uint64 range. rounds
+// controls the number of Miller-Rabin rounds; the error probability is at
+// most 4^-rounds.
+func IsPrimeProbabilistic(n *big.Int, rounds int) bool {
+	return n.ProbablyPrime(rounds)
+}
+
+// VerifyPrimes checks every value in primes with IsPrime, returning an error
+// naming the first non-prime found. It's meant as a single correctness gate
+// that sieve algorithm changes (wheel, bit-packed, range-based, ...) can all
+// be fuzz-tested against. With parallel set, the check is split across
+// runtime.NumCPU() goroutines.
+func VerifyPrimes(primes []int, parallel bool) error {
+	verify := func(start, end int) error {
+		for i := start; i < end; i++ {
+			p := primes[i]
+			if p < 0 || !IsPrime(uint64(p)) {
+				return fmt.Errorf("prime.VerifyPrimes: index %d value %d is not prime", i, p)
+			}
+		}
+		return nil
+	}
+
+	if !parallel || len(primes) == 0 {
+		return verify(0, len(primes))
+	}
+
+	workers := runtime.NumCPU()
+	chunk := (len(primes) + workers - 1) / workers
+
+	errs := make(chan error, workers)
+	var wg sync.WaitGroup
+	for start := 0; start < len(primes); start += chunk {
+		end := start + chunk
+		if end > len(primes) {
+			end = len(primes)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			if err := verify(start, end); err != nil {
+				errs <- err
+			}
+		}(start, end)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		return err
+	}
+	return nil
+}
+
+// ProgressTracker is the atomics-based home for both the coarse segment
+// progress used by the CLI progress bar and, optionally, the finer-grained
+// counters exposed via ServeMetrics for diagnosing stragglers in long-running
+// parallel sieves.
 type ProgressTracker struct {
 	total     int64
 	completed int64
+
+	primesFound int64
+	bufferHits  int64
+	bufferMisses int64
+	workerBusy  []int64 // nanoseconds spent crossing-off/collecting, indexed by worker id
 }
 
 func NewProgressTracker(total int64) *ProgressTracker {
 	return &ProgressTracker{total: total}
 }
 
+// NewProgressTrackerWithWorkers is like NewProgressTracker but also sizes the
+// per-worker busy-time counters used by ServeMetrics.
+func NewProgressTrackerWithWorkers(total int64, workers int) *ProgressTracker {
+	return &ProgressTracker{total: total, workerBusy: make([]int64, workers)}
+}
+
 func (p *ProgressTracker) AddCompleted(delta int64) {
 	atomic.AddInt64(&p.completed, delta)
 }
@@ -315,3 +1267,75 @@ func (p *ProgressTracker) GetPercent() int {
 	}
 	return int(float64(p.completed) / float64(p.total) * 100)
 }
+
+// AddPrimesFound accumulates the count of primes emitted so far, for the
+// prime_sieve_primes_found gauge.
+func (p *ProgressTracker) AddPrimesFound(delta int64) {
+	atomic.AddInt64(&p.primesFound, delta)
+}
+
+// AddBufferHit/AddBufferMiss track sync.Pool buffer reuse.
+func (p *ProgressTracker) AddBufferHit()  { atomic.AddInt64(&p.bufferHits, 1) }
+func (p *ProgressTracker) AddBufferMiss() { atomic.AddInt64(&p.bufferMisses, 1) }
+
+// AddWorkerBusy accumulates time worker spent sieving (crossing-off plus
+// collection). worker must be < the workers count passed to
+// NewProgressTrackerWithWorkers.
+func (p *ProgressTracker) AddWorkerBusy(worker int, d time.Duration) {
+	if worker < 0 || worker >= len(p.workerBusy) {
+		return
+	}
+	atomic.AddInt64(&p.workerBusy[worker], int64(d))
+}
+
+// ServeHTTP renders the tracker's counters in Prometheus text exposition
+// format.
+func (p *ProgressTracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	fmt.Fprintf(w, "# HELP prime_sieve_segments_total Total number of segments to process.\n")
+	fmt.Fprintf(w, "# TYPE prime_sieve_segments_total gauge\n")
+	fmt.Fprintf(w, "prime_sieve_segments_total %d\n", p.total)
+
+	fmt.Fprintf(w, "# HELP prime_sieve_segments_completed Number of segments processed so far.\n")
+	fmt.Fprintf(w, "# TYPE prime_sieve_segments_completed gauge\n")
+	fmt.Fprintf(w, "prime_sieve_segments_completed %d\n", atomic.LoadInt64(&p.completed))
+
+	fmt.Fprintf(w, "# HELP prime_sieve_primes_found Number of primes emitted so far.\n")
+	fmt.Fprintf(w, "# TYPE prime_sieve_primes_found gauge\n")
+	fmt.Fprintf(w, "prime_sieve_primes_found %d\n", atomic.LoadInt64(&p.primesFound))
+
+	fmt.Fprintf(w, "# HELP prime_sieve_buffer_pool_hits_total Segment buffers reused from the pool.\n")
+	fmt.Fprintf(w, "# TYPE prime_sieve_buffer_pool_hits_total counter\n")
+	fmt.Fprintf(w, "prime_sieve_buffer_pool_hits_total %d\n", atomic.LoadInt64(&p.bufferHits))
+
+	fmt.Fprintf(w, "# HELP prime_sieve_buffer_pool_misses_total Segment buffers freshly allocated.\n")
+	fmt.Fprintf(w, "# TYPE prime_sieve_buffer_pool_misses_total counter\n")
+	fmt.Fprintf(w, "prime_sieve_buffer_pool_misses_total %d\n", atomic.LoadInt64(&p.bufferMisses))
+
+	if len(p.workerBusy) > 0 {
+		fmt.Fprintf(w, "# HELP prime_sieve_worker_busy_seconds Cumulative time each worker has spent sieving.\n")
+		fmt.Fprintf(w, "# TYPE prime_sieve_worker_busy_seconds gauge\n")
+		for i := range p.workerBusy {
+			seconds := time.Duration(atomic.LoadInt64(&p.workerBusy[i])).Seconds()
+			fmt.Fprintf(w, "prime_sieve_worker_busy_seconds{worker=\"%d\"} %f\n", i, seconds)
+		}
+	}
+}
+
+// ServeMetrics starts an HTTP listener on addr serving this tracker's
+// counters at /metrics, returning the server so callers can Shutdown it.
+func (p *ProgressTracker) ServeMetrics(addr string) (*http.Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", p)
+	srv := &http.Server{Addr: addr, Handler: mux}
+
+	go srv.Serve(ln)
+
+	return srv, nil
+}
@@ -0,0 +1,108 @@
+package prime
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func BenchmarkSieveOfEratosthenes(b *testing.B) {
+	testSizes := []int{1000, 10000, 100000, 1000000}
+
+	for _, n := range testSizes {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				SieveOfEratosthenes(n)
+			}
+		})
+	}
+}
+
+func BenchmarkSegmentedSieve(b *testing.B) {
+	testSizes := []int{1000000, 5000000, 10000000}
+	ctx := context.Background()
+
+	for _, n := range testSizes {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				SegmentedSieve(ctx, n, DefaultSegmentSize, nil)
+			}
+		})
+	}
+}
+
+// BenchmarkWheelSegmentedSieve benchmarks the mod-210 wheel variant added
+// alongside SegmentedSieve, at the same n values as BenchmarkSegmentedSieve
+// so the two can be compared directly.
+func BenchmarkWheelSegmentedSieve(b *testing.B) {
+	testSizes := []int{1000000, 5000000, 10000000}
+	ctx := context.Background()
+
+	for _, n := range testSizes {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				WheelSegmentedSieve(ctx, n, DefaultSegmentSize, nil)
+			}
+		})
+	}
+}
+
+func BenchmarkParallelSegmentedSieve(b *testing.B) {
+	n := 100000000
+	workerCounts := []int{2, 4, 8}
+	ctx := context.Background()
+
+	for _, workers := range workerCounts {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				ParallelSegmentedSieve(ctx, n, workers, DefaultSegmentSize, nil, nil)
+			}
+		})
+	}
+}
+
+func BenchmarkParallelWheelSegmentedSieve(b *testing.B) {
+	n := 100000000
+	workerCounts := []int{2, 4, 8}
+	ctx := context.Background()
+
+	for _, workers := range workerCounts {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				ParallelWheelSegmentedSieve(ctx, n, workers, DefaultSegmentSize, nil)
+			}
+		})
+	}
+}
+
+// BenchmarkCompareWheelVsPlain puts the two segmented-sieve variants
+// side-by-side at a single n, for a quick sanity check of the ~1.5-2x
+// speedup the mod-210 wheel is expected to deliver.
+func BenchmarkCompareWheelVsPlain(b *testing.B) {
+	n := 10000000
+	ctx := context.Background()
+
+	b.Run("Plain", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			SegmentedSieve(ctx, n, DefaultSegmentSize, nil)
+		}
+	})
+
+	b.Run("Wheel210", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			WheelSegmentedSieve(ctx, n, DefaultSegmentSize, nil)
+		}
+	})
+}
@@ -0,0 +1,276 @@
+package prime
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+)
+
+const (
+	primeTableMagic        = "PRIM"
+	primeTableVersion byte = 1
+	// primeTableIndexStride is how often a sparse index checkpoint is
+	// recorded, trading index size for decode-from-checkpoint work.
+	primeTableIndexStride = 4096
+)
+
+type primeTableIndexEntry struct {
+	prime  uint64
+	offset uint64 // byte offset, within the gap stream, of this prime's own gap
+}
+
+// SavePrimes writes primes in a compact on-disk format: a fixed header, a
+// sparse index (one {prime, offset} pair every primeTableIndexStride
+// entries), then the primes themselves as delta-gap varints. Gaps between
+// primes near 10^9 almost all fit in a single byte, so this is roughly 8x
+// smaller than a raw uint64 table.
+func SavePrimes(w io.Writer, primes []int) error {
+	var maxPrime uint64
+	if len(primes) > 0 {
+		maxPrime = uint64(primes[len(primes)-1])
+	}
+
+	header := make([]byte, 24)
+	copy(header[0:4], primeTableMagic)
+	header[4] = primeTableVersion
+	binary.BigEndian.PutUint64(header[8:16], uint64(len(primes)))
+	binary.BigEndian.PutUint64(header[16:24], maxPrime)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	var gaps []byte
+	var index []primeTableIndexEntry
+	varintBuf := make([]byte, binary.MaxVarintLen64)
+	prev := 0
+	for i, p := range primes {
+		if i%primeTableIndexStride == 0 {
+			index = append(index, primeTableIndexEntry{prime: uint64(p), offset: uint64(len(gaps))})
+		}
+		n := binary.PutUvarint(varintBuf, uint64(p-prev))
+		gaps = append(gaps, varintBuf[:n]...)
+		prev = p
+	}
+
+	idxCountBuf := make([]byte, 8)
+	binary.BigEndian.PutUint64(idxCountBuf, uint64(len(index)))
+	if _, err := w.Write(idxCountBuf); err != nil {
+		return err
+	}
+	entryBuf := make([]byte, 16)
+	for _, e := range index {
+		binary.BigEndian.PutUint64(entryBuf[0:8], e.prime)
+		binary.BigEndian.PutUint64(entryBuf[8:16], e.offset)
+		if _, err := w.Write(entryBuf); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write(gaps)
+	return err
+}
+
+// LoadPrimes reads the format written by SavePrimes, fully decoding it into
+// a []int. Callers who only need random access to a subset should use
+// OpenPrimeTable instead.
+func LoadPrimes(r io.Reader) ([]int, error) {
+	header := make([]byte, 24)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	if string(header[0:4]) != primeTableMagic {
+		return nil, fmt.Errorf("prime.LoadPrimes: bad magic")
+	}
+	if header[4] != primeTableVersion {
+		return nil, fmt.Errorf("prime.LoadPrimes: unsupported version %d", header[4])
+	}
+	count := binary.BigEndian.Uint64(header[8:16])
+
+	idxCountBuf := make([]byte, 8)
+	if _, err := io.ReadFull(r, idxCountBuf); err != nil {
+		return nil, err
+	}
+	idxCount := binary.BigEndian.Uint64(idxCountBuf)
+	if idxCount > 0 {
+		if _, err := io.CopyN(io.Discard, r, int64(idxCount)*16); err != nil {
+			return nil, err
+		}
+	}
+
+	br := bufio.NewReader(r)
+	primes := make([]int, 0, count)
+	prev := 0
+	for i := uint64(0); i < count; i++ {
+		gap, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		prev += int(gap)
+		primes = append(primes, prev)
+	}
+	return primes, nil
+}
+
+// PrimeTable is a read-only, mmap-backed view of a prime table written by
+// SavePrimes. Gaps are decoded lazily from the nearest preceding sparse
+// index checkpoint, so At and Search only touch a bounded slice of the file.
+type PrimeTable struct {
+	f         *os.File
+	data      []byte
+	count     int
+	maxPrime  int
+	index     []primeTableIndexEntry
+	gapOffset int
+}
+
+// OpenPrimeTable mmaps path and parses its header and sparse index.
+func OpenPrimeTable(path string) (*PrimeTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	size := info.Size()
+	if size < 32 {
+		f.Close()
+		return nil, fmt.Errorf("prime.OpenPrimeTable: %s is too small to be a prime table", path)
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if string(data[0:4]) != primeTableMagic {
+		syscall.Munmap(data)
+		f.Close()
+		return nil, fmt.Errorf("prime.OpenPrimeTable: %s has a bad magic header", path)
+	}
+	if data[4] != primeTableVersion {
+		syscall.Munmap(data)
+		f.Close()
+		return nil, fmt.Errorf("prime.OpenPrimeTable: unsupported version %d", data[4])
+	}
+
+	count := binary.BigEndian.Uint64(data[8:16])
+	maxPrime := binary.BigEndian.Uint64(data[16:24])
+	idxCount := binary.BigEndian.Uint64(data[24:32])
+
+	index := make([]primeTableIndexEntry, idxCount)
+	off := 32
+	for i := range index {
+		index[i].prime = binary.BigEndian.Uint64(data[off : off+8])
+		index[i].offset = binary.BigEndian.Uint64(data[off+8 : off+16])
+		off += 16
+	}
+
+	return &PrimeTable{
+		f:         f,
+		data:      data,
+		count:     int(count),
+		maxPrime:  int(maxPrime),
+		index:     index,
+		gapOffset: off,
+	}, nil
+}
+
+// Close unmaps the table and closes the underlying file.
+func (t *PrimeTable) Close() error {
+	if err := syscall.Munmap(t.data); err != nil {
+		return err
+	}
+	return t.f.Close()
+}
+
+// Len returns the number of primes in the table.
+func (t *PrimeTable) Len() int {
+	return t.count
+}
+
+// At returns the i-th prime (0-indexed, ascending), decoding from the
+// nearest preceding sparse-index checkpoint forward.
+func (t *PrimeTable) At(i int) int {
+	if i < 0 || i >= t.count {
+		panic("prime.PrimeTable.At: index out of range")
+	}
+
+	block := i / primeTableIndexStride
+	entry := t.index[block]
+	start := block * primeTableIndexStride
+	if i == start {
+		return int(entry.prime)
+	}
+
+	pos := t.gapOffset + int(entry.offset)
+	_, n := binary.Uvarint(t.data[pos:]) // skip the checkpoint entry's own gap
+	pos += n
+
+	val := int(entry.prime)
+	for j := start + 1; j <= i; j++ {
+		gap, n := binary.Uvarint(t.data[pos:])
+		pos += n
+		val += int(gap)
+	}
+	return val
+}
+
+// Search returns the index of p in the table and true if present, or the
+// index of the first entry greater than p and false otherwise. It touches
+// at most one sparse-index binary search plus one block (primeTableIndexStride
+// entries) of sequential decoding.
+func (t *PrimeTable) Search(p int) (int, bool) {
+	if t.count == 0 {
+		return 0, false
+	}
+
+	lo, hi, blk := 0, len(t.index)-1, 0
+	for lo <= hi {
+		mid := (lo + hi) / 2
+		if int(t.index[mid].prime) <= p {
+			blk = mid
+			lo = mid + 1
+		} else {
+			hi = mid - 1
+		}
+	}
+
+	start := blk * primeTableIndexStride
+	end := start + primeTableIndexStride
+	if end > t.count {
+		end = t.count
+	}
+
+	val := int(t.index[blk].prime)
+	if val == p {
+		return start, true
+	}
+	if val > p {
+		return start, false
+	}
+
+	pos := t.gapOffset + int(t.index[blk].offset)
+	_, n := binary.Uvarint(t.data[pos:])
+	pos += n
+
+	for i := start + 1; i < end; i++ {
+		gap, n := binary.Uvarint(t.data[pos:])
+		pos += n
+		val += int(gap)
+		if val == p {
+			return i, true
+		}
+		if val > p {
+			return i, false
+		}
+	}
+	return end, false
+}
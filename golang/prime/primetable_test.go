@@ -0,0 +1,141 @@
+package prime
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSavePrimesLoadPrimesRoundTrip pins SavePrimes/LoadPrimes' gap-varint
+// encoding against a prime set large enough to span multiple sparse-index
+// blocks (primeTableIndexStride = 4096).
+func TestSavePrimesLoadPrimesRoundTrip(t *testing.T) {
+	want := SieveOfEratosthenes(100000)
+
+	var buf bytes.Buffer
+	if err := SavePrimes(&buf, want); err != nil {
+		t.Fatalf("SavePrimes: %v", err)
+	}
+
+	got, err := LoadPrimes(&buf)
+	if err != nil {
+		t.Fatalf("LoadPrimes: %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("LoadPrimes produced %d primes, want %d", len(got), len(want))
+	}
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("LoadPrimes[%d] = %d, want %d", i, got[i], v)
+		}
+	}
+}
+
+// TestSavePrimesEmpty checks the format round-trips an empty prime set.
+func TestSavePrimesEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SavePrimes(&buf, nil); err != nil {
+		t.Fatalf("SavePrimes: %v", err)
+	}
+
+	got, err := LoadPrimes(&buf)
+	if err != nil {
+		t.Fatalf("LoadPrimes: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("LoadPrimes = %v, want empty", got)
+	}
+}
+
+// TestOpenPrimeTableAtAndSearch pins OpenPrimeTable's mmap-backed At/Search
+// against a plain in-memory prime slice across multiple sparse-index blocks
+// and at block boundaries.
+func TestOpenPrimeTableAtAndSearch(t *testing.T) {
+	want := SieveOfEratosthenes(100000)
+
+	path := filepath.Join(t.TempDir(), "primes.tbl")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("os.Create: %v", err)
+	}
+	if err := SavePrimes(f, want); err != nil {
+		t.Fatalf("SavePrimes: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("f.Close: %v", err)
+	}
+
+	table, err := OpenPrimeTable(path)
+	if err != nil {
+		t.Fatalf("OpenPrimeTable: %v", err)
+	}
+	defer table.Close()
+
+	if table.Len() != len(want) {
+		t.Fatalf("table.Len() = %d, want %d", table.Len(), len(want))
+	}
+
+	indices := []int{0, 1, primeTableIndexStride - 1, primeTableIndexStride, primeTableIndexStride + 1, len(want) / 2, len(want) - 1}
+	for _, i := range indices {
+		if got := table.At(i); got != want[i] {
+			t.Errorf("table.At(%d) = %d, want %d", i, got, want[i])
+		}
+	}
+
+	for _, i := range indices {
+		idx, found := table.Search(want[i])
+		if !found {
+			t.Errorf("table.Search(%d) did not find it, want index %d", want[i], i)
+			continue
+		}
+		if idx != i {
+			t.Errorf("table.Search(%d) = %d, want %d", want[i], idx, i)
+		}
+	}
+
+	// A value between two consecutive primes should report not-found and
+	// the index of the next prime.
+	gapValue := want[10] + 1
+	if want[11] == want[10]+1 {
+		t.Fatalf("test fixture assumption broken: primes[10] and [11] are consecutive")
+	}
+	idx, found := table.Search(gapValue)
+	if found {
+		t.Errorf("table.Search(%d) reported found, want not found", gapValue)
+	}
+	if idx != 11 {
+		t.Errorf("table.Search(%d) = %d, want 11", gapValue, idx)
+	}
+}
+
+// TestPrimeTableAtOutOfRangePanics documents At's documented panic on an
+// out-of-range index.
+func TestPrimeTableAtOutOfRangePanics(t *testing.T) {
+	want := SieveOfEratosthenes(1000)
+	path := filepath.Join(t.TempDir(), "primes.tbl")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("os.Create: %v", err)
+	}
+	if err := SavePrimes(f, want); err != nil {
+		t.Fatalf("SavePrimes: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("f.Close: %v", err)
+	}
+
+	table, err := OpenPrimeTable(path)
+	if err != nil {
+		t.Fatalf("OpenPrimeTable: %v", err)
+	}
+	defer table.Close()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("table.At(out of range) did not panic")
+		}
+	}()
+	table.At(table.Len())
+}
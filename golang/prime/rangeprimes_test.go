@@ -0,0 +1,45 @@
+package prime
+
+import "testing"
+
+// TestPrimesInRangeNonzeroLo pins PrimesInRange for lo > 0, since every
+// existing test only ever exercised lo=0.
+func TestPrimesInRangeNonzeroLo(t *testing.T) {
+	lo, hi := int64(100), int64(1000)
+	want := bruteForcePrimes(int(hi))
+	var wantTail []int64
+	for _, p := range want {
+		if int64(p) >= lo {
+			wantTail = append(wantTail, int64(p))
+		}
+	}
+
+	got := PrimesInRange(lo, hi, 50, nil)
+
+	if len(got) != len(wantTail) {
+		t.Fatalf("PrimesInRange(%d, %d) produced %d primes, want %d", lo, hi, len(got), len(wantTail))
+	}
+	for i, v := range wantTail {
+		if got[i] != v {
+			t.Errorf("PrimesInRange(%d, %d)[%d] = %d, want %d", lo, hi, i, got[i], v)
+		}
+	}
+}
+
+// TestParallelPrimesInRangeMatchesSequential pins ParallelPrimesInRange
+// against PrimesInRange over a lo > 0 window, the parallel counterpart
+// chunk0-1 added alongside PrimesInRange.
+func TestParallelPrimesInRangeMatchesSequential(t *testing.T) {
+	lo, hi := int64(500), int64(20000)
+	want := PrimesInRange(lo, hi, 200, nil)
+	got := ParallelPrimesInRange(lo, hi, 4, 200, nil)
+
+	if len(got) != len(want) {
+		t.Fatalf("ParallelPrimesInRange(%d, %d) produced %d primes, want %d", lo, hi, len(got), len(want))
+	}
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("ParallelPrimesInRange(%d, %d)[%d] = %d, want %d", lo, hi, i, got[i], v)
+		}
+	}
+}
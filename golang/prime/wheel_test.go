@@ -0,0 +1,83 @@
+package prime
+
+import (
+	"context"
+	"testing"
+)
+
+// bruteForcePrimes trial-divides every candidate below n, independent of any
+// sieve implementation, as ground truth for pinning the wheel sieve.
+func bruteForcePrimes(n int) []int {
+	var primes []int
+	for v := 2; v < n; v++ {
+		isPrime := true
+		for d := 2; d*d <= v; d++ {
+			if v%d == 0 {
+				isPrime = false
+				break
+			}
+		}
+		if isPrime {
+			primes = append(primes, v)
+		}
+	}
+	return primes
+}
+
+// TestWheelSegmentedSieveMatchesBruteForce pins WheelSegmentedSieve against
+// trial division rather than just SegmentedSieve, so a crossing-off bug
+// shared between the two code paths can't hide. n=1000 is the value that
+// first exposed the wheel-210 index-conflation bug fixed in d03ad3a (missing
+// 127, spurious composite 143).
+func TestWheelSegmentedSieveMatchesBruteForce(t *testing.T) {
+	tests := []int{10, 100, 210, 1000, 5000, 50000}
+	for _, n := range tests {
+		want := bruteForcePrimes(n)
+		got := WheelSegmentedSieve(context.Background(), n, 100, nil)
+
+		if len(got) != len(want) {
+			t.Fatalf("WheelSegmentedSieve(%d) produced %d primes, want %d (%v vs %v)", n, len(got), len(want), got, want)
+		}
+		for i, v := range want {
+			if got[i] != v {
+				t.Errorf("WheelSegmentedSieve(%d)[%d] = %d, want %d", n, i, got[i], v)
+			}
+		}
+	}
+}
+
+func TestParallelWheelSegmentedSieveMatchesBruteForce(t *testing.T) {
+	tests := []int{1000, 50000}
+	for _, n := range tests {
+		want := bruteForcePrimes(n)
+		got := ParallelWheelSegmentedSieve(context.Background(), n, 2, 100, nil)
+
+		if len(got) != len(want) {
+			t.Fatalf("ParallelWheelSegmentedSieve(%d) produced %d primes, want %d", n, len(got), len(want))
+		}
+		for i, v := range want {
+			if got[i] != v {
+				t.Errorf("ParallelWheelSegmentedSieve(%d)[%d] = %d, want %d", n, i, got[i], v)
+			}
+		}
+	}
+}
+
+// TestWheelSegmentedSieveSegmentOffset exercises a segment boundary that
+// doesn't start at 0, the other scenario the index-conflation bug depended
+// on (crossing-off position computed from the wrong residue only diverges
+// from the correct one partway through a block).
+func TestWheelSegmentedSieveSegmentOffset(t *testing.T) {
+	n := 5000
+	want := bruteForcePrimes(n)
+	got := WheelSegmentedSieve(context.Background(), n, 210, nil)
+
+	if len(got) != len(want) {
+		t.Fatalf("WheelSegmentedSieve(%d, segmentSize=210) produced %d primes, want %d", n, len(got), len(want))
+	}
+	for i, v := range want {
+		if got[i] != v {
+			t.Errorf("WheelSegmentedSieve(%d, segmentSize=210)[%d] = %d, want %d", n, i, got[i], v)
+		}
+	}
+}